@@ -0,0 +1,139 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// AnyGroup is a view onto a [PluginGroup] that is independent of the group's
+// particular symbol type, for tooling working across multiple plugin groups.
+type AnyGroup interface {
+	SymbolType() reflect.Type // symbol type of the plugin group.
+	String() string           // textual representation of the plugin group.
+	Len() int                 // number of exposed symbols.
+	Registrations() uint64    // number of symbol registrations since creation.
+	Plugins() []string        // ordered names of the plugins.
+
+	// RegisterAny registers a plugin-exposed symbol only known at runtime,
+	// with optional additional registration information, returning an error if
+	// the symbol isn't assignable to the group's symbol type or is invalid.
+	RegisterAny(symbol any, opts ...RegisterOption) error
+}
+
+var _ AnyGroup = (*PluginGroup[any])(nil)
+
+// SymbolType returns the symbol type of this plugin group.
+func (g *PluginGroup[T]) SymbolType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// RegisterAny registers a plugin-exposed symbol only known at runtime with this
+// plugin group, with optional additional registration information. In
+// contrast to [PluginGroup.Register], RegisterAny returns an error instead of
+// panicking if the symbol isn't assignable to the group's symbol type or is
+// invalid otherwise.
+func (g *PluginGroup[T]) RegisterAny(symbol any, opts ...RegisterOption) error {
+	return g.registerAny(symbol, opts, 1)
+}
+
+// LookupGroup returns the plugin group for the symbol type with the specified
+// name and true, or nil and false if there is no such plugin group. The
+// symbol type name consists of the type's package import path and type name,
+// such as "github.com/example/foo.Plugin", or is the textual representation
+// for unnamed types, such as "func() string".
+func LookupGroup(name string) (AnyGroup, bool) {
+	groupsmu.Lock()
+	defer groupsmu.Unlock()
+	if group, ok := groupsByName[name]; ok {
+		return group.(AnyGroup), true
+	}
+	for t, group := range groups {
+		if groupKeyName(t) == name {
+			return group.(AnyGroup), true
+		}
+	}
+	return nil, false
+}
+
+// GroupsImplementing returns the plugin groups whose symbol types implement the
+// specified interface type, sorted by the groups' symbol type names. For
+// instance, reflect.TypeFor[io.Closer]() returns all plugin groups with symbols
+// that can be closed. GroupsImplementing panics if iface isn't an interface
+// type.
+func GroupsImplementing(iface reflect.Type) []AnyGroup {
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Errorf("%w, but got %s", ErrNotInterface, iface))
+	}
+	var matching []AnyGroup
+	for _, group := range allGroups() {
+		if group.SymbolType().Implements(iface) {
+			matching = append(matching, group)
+		}
+	}
+	return matching
+}
+
+// PluginParticipation returns the symbol types of all plugin groups the named
+// plugin has registered symbols with, sorted by the symbol type names. This
+// gives a plugin-centric view onto the plugin groups, such as for a logical
+// plugin exposing symbols in multiple groups.
+func PluginParticipation(name string) []reflect.Type {
+	var types []reflect.Type
+	for _, group := range allGroups() {
+		if group.hasPlugin(name) {
+			types = append(types, group.SymbolType())
+		}
+	}
+	return types
+}
+
+// hasPlugin returns true if the named plugin exposes a symbol in this plugin
+// group.
+func (g *PluginGroup[T]) hasPlugin(name string) bool {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+	_, ok := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	return ok
+}
+
+// allGroups returns all plugin groups, sorted by the groups' symbol type names.
+// As allGroups doesn't hold the groups lock anymore when returning, callers can
+// safely work on the individual groups.
+func allGroups() []untypedGroup {
+	type namedGroup struct {
+		name  string
+		group untypedGroup
+	}
+	groupsmu.Lock()
+	named := make([]namedGroup, 0, len(groups)+len(groupsByName))
+	for t, group := range groups {
+		named = append(named, namedGroup{name: groupKeyName(t), group: group.(untypedGroup)})
+	}
+	for name, group := range groupsByName {
+		named = append(named, namedGroup{name: name, group: group.(untypedGroup)})
+	}
+	groupsmu.Unlock()
+	sort.SliceStable(named, func(a, b int) bool {
+		return named[a].name < named[b].name
+	})
+	all := make([]untypedGroup, 0, len(named))
+	for _, n := range named {
+		all = append(all, n.group)
+	}
+	return all
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type-independent plugin groups", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+		groupsByName = map[string]any{}
+	})
+
+	It("returns the groups implementing an interface", func() {
+		Expect(func() { GroupsImplementing(reflect.TypeFor[fooFn]()) }).To(PanicWith(MatchError(
+			"type must be interface, but got plugger.fooFn")))
+
+		Group[fooFn]()
+		stringers := Group[fmt.Stringer]()
+		foos := Group[fooIf]()
+		foos.Register(&fooImpl{s: "foo"}, WithPlugin("foo"))
+		Expect(foos.SymbolType()).To(Equal(reflect.TypeFor[fooIf]()))
+
+		matching := GroupsImplementing(reflect.TypeFor[interface{ Foo() string }]())
+		Expect(matching).To(HaveLen(1))
+		Expect(matching[0]).To(BeIdenticalTo(foos))
+		Expect(matching[0].Plugins()).To(Equal([]string{"foo"}))
+
+		Expect(GroupsImplementing(reflect.TypeFor[any]())).To(Equal([]AnyGroup{
+			stringers, Group[fooFn](), foos}))
+	})
+
+	It("looks up groups by name and registers untyped symbols", func() {
+		_, ok := LookupGroup("github.com/thediveo/go-plugger/v3.fooFn")
+		Expect(ok).To(BeFalse())
+
+		Group[fooFn]()
+		g, ok := LookupGroup("github.com/thediveo/go-plugger/v3.fooFn")
+		Expect(ok).To(BeTrue())
+		Expect(g.SymbolType()).To(Equal(reflect.TypeFor[fooFn]()))
+		Expect(g.RegisterAny(func() string { return "one" }, WithPlugin("one"))).To(Succeed())
+		Expect(g.RegisterAny(42)).To(MatchError(
+			"invalid symbol type: int is not assignable to plugger.fooFn"))
+		Expect(g.RegisterAny(fooFn(nil))).To(MatchError("func symbol must not be nil"))
+		Expect(Group[fooFn]().Plugins()).To(Equal([]string{"one"}))
+		Expect(g.RegisterAny(func() string { return "auto" })).To(Succeed())
+		file, line := Group[fooFn]().PluginSource("go-plugger")
+		Expect(file).To(HaveSuffix("/anygroup_test.go"))
+		Expect(line).NotTo(BeZero())
+
+		groupsByName = map[string]any{}
+		UseNameBasedGroupKeys(true)
+		defer UseNameBasedGroupKeys(false)
+		Group[barFn]()
+		_, ok = LookupGroup("github.com/thediveo/go-plugger/v3.barFn")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("returns the groups a plugin participates in", func() {
+		Expect(PluginParticipation("foo")).To(BeEmpty())
+
+		Group[fooIf]().Register(&fooImpl{s: "foo"}, WithPlugin("foo"))
+		Group[fooFn]().Register(func() string { return "foo" }, WithPlugin("foo"))
+		Group[barFn]().Register(func() string { return "bar" }, WithPlugin("bar"))
+		Expect(PluginParticipation("foo")).To(Equal([]reflect.Type{
+			reflect.TypeFor[fooFn](),
+			reflect.TypeFor[fooIf](),
+		}))
+		Expect(PluginParticipation("bar")).To(Equal([]reflect.Type{reflect.TypeFor[barFn]()}))
+		Expect(PluginParticipation("baz")).To(BeEmpty())
+	})
+
+})
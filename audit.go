@@ -0,0 +1,219 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// AuditKind identifies the kind of an [AuditIssue].
+type AuditKind string
+
+// The kinds of issues reported by [PluginGroup.Audit].
+const (
+	AuditDanglingPlacement      AuditKind = "dangling-placement"      // placement references an unknown plugin.
+	AuditContradictoryPlacement AuditKind = "contradictory-placement" // plugins want to be placed relative to each other in contradictory ways.
+	AuditUnstablePlacement      AuditKind = "unstable-placement"      // placements don't stabilize.
+	AuditDuplicateName          AuditKind = "duplicate-name"          // multiple symbols for the same plugin name.
+	AuditDuplicateID            AuditKind = "duplicate-id"            // multiple symbols with the same plugin ID.
+)
+
+// AuditIssue describes an issue found by [PluginGroup.Audit] with the plugins
+// registered in a plugin group.
+type AuditIssue struct {
+	Kind    AuditKind // kind of issue.
+	Plugins []string  // names of the plugins involved.
+	Message string    // human-readable description of the issue.
+}
+
+// String returns the human-readable description of the issue.
+func (i AuditIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Kind, i.Message)
+}
+
+// Audit checks the plugins currently exposed by this plugin group for
+// ambiguous or broken wiring, returning the issues found, if any. Audit is
+// intended to be used in unit tests in order to guard the plugin wiring of an
+// application:
+//
+//	Expect(plugger.Group[fooFn]().Audit()).To(BeEmpty())
+//
+// Audit reports:
+//   - placements referencing unknown plugins,
+//   - pairs of plugins wanting to be placed before (or after) each other,
+//   - placements that don't stabilize, such as placement cycles,
+//   - multiple symbols registered for the same plugin name,
+//   - multiple symbols registered with the same plugin ID.
+func (g *PluginGroup[T]) Audit() []AuditIssue {
+	g.lock()
+	symbols := slices.Clone(g.placements(g.symbols))
+	ordering, collation := g.ordering, g.collation
+	g.unlock()
+
+	var issues []AuditIssue
+	names := map[string]Symbol[T]{}
+	ids := map[uint32]string{}
+	for _, symbol := range symbols {
+		if _, ok := names[symbol.Plugin]; ok {
+			issues = append(issues, AuditIssue{
+				Kind:    AuditDuplicateName,
+				Plugins: []string{symbol.Plugin},
+				Message: fmt.Sprintf("plugin %q registered multiple symbols", symbol.Plugin),
+			})
+		}
+		names[symbol.Plugin] = symbol
+		if symbol.ID == 0 {
+			continue
+		}
+		if plugin, ok := ids[symbol.ID]; ok {
+			issues = append(issues, AuditIssue{
+				Kind:    AuditDuplicateID,
+				Plugins: []string{plugin, symbol.Plugin},
+				Message: fmt.Sprintf("plugins %q and %q share ID %d",
+					plugin, symbol.Plugin, symbol.ID),
+			})
+			continue
+		}
+		ids[symbol.ID] = symbol.Plugin
+	}
+	for _, symbol := range symbols {
+		if !hasAnchor(symbol.Placement) {
+			continue
+		}
+		name, _, ok := placementRef(symbol.Placement[1:], func(name string) bool {
+			_, ok := names[name]
+			return ok
+		})
+		if !ok {
+			issues = append(issues, AuditIssue{
+				Kind:    AuditDanglingPlacement,
+				Plugins: []string{symbol.Plugin},
+				Message: fmt.Sprintf("plugin %q references unknown plugin in placement %q",
+					symbol.Plugin, symbol.Placement),
+			})
+			continue
+		}
+		ref := names[name]
+		// Report contradictory pairs only once, from the perspective of the
+		// lexicographically first plugin. Adjacent placements never contradict
+		// each other.
+		if symbol.Placement[0] != '~' && symbol.Plugin < ref.Plugin &&
+			ref.Placement == symbol.Placement[:1]+symbol.Plugin {
+			issues = append(issues, AuditIssue{
+				Kind:    AuditContradictoryPlacement,
+				Plugins: []string{symbol.Plugin, ref.Plugin},
+				Message: fmt.Sprintf("plugins %q (%q) and %q (%q) contradict each other",
+					symbol.Plugin, symbol.Placement, ref.Plugin, ref.Placement),
+			})
+		}
+	}
+	order(symbols, ordering, collation)
+	if _, conflict := resolve(symbols, symbols); conflict != nil {
+		issues = append(issues, AuditIssue{
+			Kind:    AuditUnstablePlacement,
+			Plugins: conflict.Plugins,
+			Message: conflict.Error(),
+		})
+	}
+	return issues
+}
+
+// ValidateGraph checks that the placements of the plugins currently exposed by
+// this plugin group form a fully resolvable directed acyclic graph, where a
+// placement "<foo" means “before foo” and ">foo" means “after foo”, while
+// "~foo" (“next to foo”) doesn't imply any direction.
+// ValidateGraph returns an error describing all placements referencing unknown
+// plugins, as well as all cycles together with their paths; otherwise, it
+// returns nil. In contrast to [PluginGroup.Audit], ValidateGraph is intended
+// as a strict startup assertion that the plugin wiring is sound.
+func (g *PluginGroup[T]) ValidateGraph() error {
+	g.lock()
+	symbols := slices.Clone(g.placements(g.symbols))
+	g.unlock()
+
+	names := map[string]struct{}{}
+	for _, symbol := range symbols {
+		names[symbol.Plugin] = struct{}{}
+	}
+	var errs []error
+	// edges maps each plugin to the plugins that must come after it.
+	edges := map[string][]string{}
+	for _, symbol := range symbols {
+		if !hasAnchor(symbol.Placement) {
+			continue
+		}
+		ref, _, ok := placementRef(symbol.Placement[1:], func(name string) bool {
+			_, ok := names[name]
+			return ok
+		})
+		if !ok {
+			errs = append(errs, fmt.Errorf("plugin %q references unknown plugin in placement %q",
+				symbol.Plugin, symbol.Placement))
+			continue
+		}
+		switch symbol.Placement[0] {
+		case '<':
+			edges[symbol.Plugin] = append(edges[symbol.Plugin], ref)
+		case '>':
+			edges[ref] = append(edges[ref], symbol.Plugin)
+		}
+	}
+	// Find the cycles using a depth-first search, visiting the plugins and
+	// their edges in lexicographic order for deterministic results.
+	plugins := make([]string, 0, len(names))
+	for name := range names {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		path = append(path, name)
+		next := edges[name]
+		sort.Strings(next)
+		for _, to := range next {
+			switch state[to] {
+			case unvisited:
+				visit(to)
+			case visiting:
+				cycle := append(slices.Clone(path[slices.Index(path, to):]), to)
+				errs = append(errs, fmt.Errorf("placement cycle %s", strings.Join(cycle, " -> ")))
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+	for _, name := range plugins {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid placement graph: %w", errors.Join(errs...))
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("auditing plugin groups", func() {
+
+	It("finds no issues in sane plugin groups", func() {
+		g := &PluginGroup[fooFn]{}
+		Expect(g.Audit()).To(BeEmpty())
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<one"), WithID(2))
+		Expect(g.Audit()).To(BeEmpty())
+	})
+
+	It("reports issues", func() {
+		g := &PluginGroup[any]{
+			symbols: []Symbol[any]{
+				{Plugin: "alpha", Placement: "<beta"},
+				{Plugin: "beta", Placement: "<alpha", ID: 42},
+				{Plugin: "gamma", Placement: ">omega", ID: 42},
+				{Plugin: "gamma"},
+			},
+		}
+		Expect(g.Audit()).To(ConsistOf(
+			And(HaveField("Kind", AuditDuplicateName), HaveField("Plugins", []string{"gamma"})),
+			And(HaveField("Kind", AuditDuplicateID), HaveField("Plugins", []string{"beta", "gamma"})),
+			And(HaveField("Kind", AuditDanglingPlacement), HaveField("Plugins", []string{"gamma"})),
+			And(HaveField("Kind", AuditContradictoryPlacement), HaveField("Plugins", []string{"alpha", "beta"})),
+		))
+	})
+
+	It("reports unstable placements", func() {
+		g := &PluginGroup[any]{
+			symbols: []Symbol[any]{
+				{Plugin: "alpha", Placement: "<beta"},
+				{Plugin: "beta", Placement: "<gamma"},
+				{Plugin: "gamma", Placement: "<alpha"},
+			},
+		}
+		Expect(g.Audit()).To(ConsistOf(
+			And(HaveField("Kind", AuditUnstablePlacement),
+				HaveField("String()", ContainSubstring("did not stabilize")))))
+	})
+
+	It("validates placement graphs", func() {
+		g := &PluginGroup[fooFn]{}
+		Expect(g.ValidateGraph()).To(Succeed())
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<one"))
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement(">one+1"))
+		g.Register(func() string { return "four" }, WithPlugin("four"), WithPlacement("<"))
+		Expect(g.ValidateGraph()).To(Succeed())
+
+		g = &PluginGroup[fooFn]{}
+		g.Register(func() string { return "alpha" }, WithPlugin("alpha"), WithPlacement("<beta"))
+		g.Register(func() string { return "beta" }, WithPlugin("beta"), WithPlacement("<gamma"))
+		g.Register(func() string { return "gamma" }, WithPlugin("gamma"), WithPlacement("<alpha"))
+		g.Register(func() string { return "delta" }, WithPlugin("delta"), WithPlacement("<omega"))
+		Expect(g.ValidateGraph()).To(MatchError(
+			"invalid placement graph: " +
+				"plugin \"delta\" references unknown plugin in placement \"<omega\"\n" +
+				"placement cycle alpha -> beta -> gamma -> alpha"))
+	})
+
+})
@@ -0,0 +1,89 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+)
+
+// Override is a request-local change to the symbols of a plugin group, for use
+// with [WithContext]. Use [OverrideSymbol] and [RemoveSymbol] to create
+// Overrides.
+type Override[T any] struct {
+	plugin string
+	symbol T
+	remove bool
+}
+
+// OverrideSymbol returns an [Override] replacing the symbol of the named plugin,
+// or adding the symbol if there is no such plugin.
+func OverrideSymbol[T any](name string, symbol T) Override[T] {
+	return Override[T]{plugin: name, symbol: symbol}
+}
+
+// RemoveSymbol returns an [Override] removing the symbol of the named plugin.
+func RemoveSymbol[T any](name string) Override[T] {
+	return Override[T]{plugin: name, remove: true}
+}
+
+// overridesKey is the context key for the overrides of a particular plugin
+// group.
+type overridesKey[T any] struct {
+	g *PluginGroup[T]
+}
+
+// WithContext returns a copy of ctx carrying the specified overrides of the
+// symbols of the plugin group g, in addition to any overrides of g already
+// carried by ctx. Use [SymbolsFromContext] to get the symbols of g with the
+// overrides applied. In contrast to changing g itself, context-scoped overrides
+// only affect a single request, without affecting concurrent requests.
+func WithContext[T any](ctx context.Context, g *PluginGroup[T], overrides ...Override[T]) context.Context {
+	key := overridesKey[T]{g: g}
+	inherited, _ := ctx.Value(key).([]Override[T])
+	return context.WithValue(ctx, key,
+		append(slices.Clip(inherited), overrides...))
+}
+
+// SymbolsFromContext returns the ordered symbols of the plugin group g with the
+// overrides carried by ctx applied in the order they were added to ctx, if any.
+// Replacing symbols keep their positions, while added symbols follow after the
+// existing symbols.
+func SymbolsFromContext[T any](ctx context.Context, g *PluginGroup[T]) []T {
+	overrides, _ := ctx.Value(overridesKey[T]{g: g}).([]Override[T])
+	if len(overrides) == 0 {
+		return g.Symbols()
+	}
+	symbols := g.PluginsSymbols()
+	for _, override := range overrides {
+		idx := slices.IndexFunc(symbols, func(s Symbol[T]) bool { return s.Plugin == override.plugin })
+		switch {
+		case override.remove:
+			if idx >= 0 {
+				symbols = slices.Delete(symbols, idx, idx+1)
+			}
+		case idx >= 0:
+			symbols[idx].S = override.symbol
+		default:
+			symbols = append(symbols, Symbol[T]{S: override.symbol, Plugin: override.plugin})
+		}
+	}
+	s := make([]T, 0, len(symbols))
+	for _, symbol := range symbols {
+		s = append(s, symbol.S)
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("context-scoped overrides", func() {
+
+	call := func(symbols []fooFn) []string {
+		results := make([]string, 0, len(symbols))
+		for _, symbol := range symbols {
+			results = append(results, symbol())
+		}
+		return results
+	}
+
+	It("applies request-local overrides", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement("<"))
+
+		ctx := context.Background()
+		Expect(call(SymbolsFromContext(ctx, g))).To(Equal([]string{"three", "one", "two"}))
+
+		ctx1 := WithContext(ctx, g,
+			OverrideSymbol[fooFn]("one", func() string { return "ONE" }),
+			RemoveSymbol[fooFn]("three"),
+			RemoveSymbol[fooFn]("foo"))
+		Expect(call(SymbolsFromContext(ctx1, g))).To(Equal([]string{"ONE", "two"}))
+
+		ctx2 := WithContext(ctx1, g, OverrideSymbol[fooFn]("four", func() string { return "four" }))
+		ctx3 := WithContext(ctx1, g, OverrideSymbol[fooFn]("five", func() string { return "five" }))
+		Expect(call(SymbolsFromContext(ctx2, g))).To(Equal([]string{"ONE", "two", "four"}))
+		Expect(call(SymbolsFromContext(ctx3, g))).To(Equal([]string{"ONE", "two", "five"}))
+
+		other := &PluginGroup[fooFn]{}
+		Expect(SymbolsFromContext(ctx2, other)).To(BeEmpty())
+		Expect(call(g.Symbols())).To(Equal([]string{"three", "one", "two"}))
+	})
+
+})
@@ -0,0 +1,45 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// debug enables logging the final plugin order of each plugin group when the
+// group is used for the first time.
+var debug atomic.Bool
+
+func init() {
+	debug.Store(os.Getenv("PLUGGER_DEBUG") == "1")
+}
+
+// SetDebug enables or disables logging the final plugin order of each plugin
+// group when the group is used for the first time, using the standard logger.
+// Alternatively, debug logging can be enabled without any code changes by
+// setting the environment variable PLUGGER_DEBUG=1.
+func SetDebug(enable bool) {
+	debug.Store(enable)
+}
+
+// logOrder logs the final plugin order of this plugin group. This method must
+// be called under (read) lock.
+func (g *PluginGroup[T]) logOrder() {
+	log.Printf("plugger: group %s plugin order: [%s]",
+		groupKeyName(g.SymbolType()), strings.Join(pluginNames(g.symbols), ", "))
+}
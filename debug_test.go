@@ -0,0 +1,73 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"log"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("debug logging", func() {
+
+	It("logs the plugin order on first use", func() {
+		var logs strings.Builder
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		Expect(g.Len()).To(Equal(2))
+		Expect(logs.String()).To(BeEmpty())
+
+		g = &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		SetDebug(true)
+		defer SetDebug(false)
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(g.Len()).To(Equal(2))
+		Expect(logs.String()).To(MatchRegexp(
+			`^.* plugger: group github\.com/thediveo/go-plugger/v3\.fooFn plugin order: \[two, one\]\n$`))
+	})
+
+	It("warns once about querying empty groups", func() {
+		var logs strings.Builder
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+
+		g := &PluginGroup[fooFn]{}
+		Expect(g.Plugins()).To(BeEmpty())
+		Expect(logs.String()).To(BeEmpty())
+
+		g.WarnOnEmptyQuery(true)
+		Expect(g.Symbols()).To(BeEmpty())
+		Expect(g.Plugins()).To(BeEmpty())
+		Expect(logs.String()).To(MatchRegexp(
+			`^.* plugger: group github\.com/thediveo/go-plugger/v3\.fooFn queried before any plugin registered; missing plugin import\?\n$`))
+
+		logs.Reset()
+		g = &PluginGroup[fooFn]{}
+		g.WarnOnEmptyQuery(true)
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		Expect(g.Plugins()).To(HaveLen(1))
+		Expect(logs.String()).To(BeEmpty())
+	})
+
+})
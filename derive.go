@@ -0,0 +1,115 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/exp/slices"
+)
+
+// Derive returns a new child plugin group that inherits the symbols of this
+// plugin group, such as for per-tenant customizations of a common set of
+// plugins. The child group initially uses the same ordering, collation,
+// default placement, and winner policy as this plugin group, and exposes
+// experimental plugins if this plugin group does.
+//
+// Symbols registered with the child group override inherited symbols of the
+// same plugin name; use [PluginGroup.DisableInherited] to hide inherited plugins. Such
+// local changes, as well as reordering the child group, don't affect this
+// plugin group. In turn, the child group picks up any changes to this plugin
+// group, always inheriting its current symbols. Finalizers of inherited
+// symbols are never run by the child group.
+func (g *PluginGroup[T]) Derive() *PluginGroup[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &PluginGroup[T]{
+		parent:           g,
+		ordering:         g.ordering,
+		collation:        g.collation,
+		defaultPlacement: g.defaultPlacement,
+		winner:           g.winner,
+		experimental:     g.experimental,
+	}
+}
+
+// DisableInherited disables the named plugins inherited from the parent group
+// of this derived plugin group, without affecting the parent group. Disabling
+// doesn't affect the symbols registered with this plugin group itself; use
+// [Registration.Disable] instead. DisableInherited panics if this plugin group
+// isn't derived from a parent group.
+func (g *PluginGroup[T]) DisableInherited(names ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.parent == nil {
+		panic(fmt.Errorf("group %s has %w to disable inherited plugins of",
+			reflect.TypeFor[T](), ErrNoParent))
+	}
+	if g.disabled == nil {
+		g.disabled = map[string]bool{}
+	}
+	for _, name := range names {
+		g.disabled[name] = true
+	}
+	g.ordered = false
+}
+
+// stale returns true if this plugin group is derived and its parent group has
+// changed since last inheriting the parent's symbols. This method must be
+// called under (read) lock.
+func (g *PluginGroup[T]) stale() bool {
+	return g.parent != nil && g.parent.currentVersion() != g.parentVersion
+}
+
+// inherit the current symbols of the parent group, if any, unless overridden
+// by own symbols or disabled. This method must be called under write lock.
+func (g *PluginGroup[T]) inherit() {
+	if g.parent == nil {
+		return
+	}
+	g.parent.lock()
+	version, inherited := g.parent.version, g.parent.all()
+	g.parent.unlock()
+
+	isInherited := func(s Symbol[T]) bool { return s.inherited }
+	g.symbols = slices.DeleteFunc(g.symbols, isInherited)
+	g.hidden = slices.DeleteFunc(g.hidden, isInherited)
+	own := map[string]bool{}
+	for _, symbol := range g.all() {
+		own[symbol.Plugin] = true
+	}
+	for _, symbol := range inherited {
+		if own[symbol.Plugin] || g.disabled[symbol.Plugin] {
+			continue
+		}
+		symbol.inherited = true
+		symbol.finalizer = nil
+		g.symbols = append(g.symbols, symbol)
+	}
+	g.parentVersion = version
+}
+
+// lookupLock read-locks this plugin group for lookups that don't depend on the
+// order of the symbols. Derived plugin groups are additionally brought up to
+// date with their parent groups. The caller needs to (defer to) read-unlock
+// after having done its work.
+func (g *PluginGroup[T]) lookupLock() {
+	if g.parent != nil {
+		g.lock()
+		return
+	}
+	g.mu.RLock()
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("derived plugin groups", func() {
+
+	It("inherits, overrides, and disables parent plugins", func() {
+		var finalized []string
+		parent := &PluginGroup[fooFn]{}
+		parent.Register(func() string { return "a" }, WithPlugin("a"))
+		parent.Register(func() string { return "b" }, WithPlugin("b"),
+			WithFinalizer(func() { finalized = append(finalized, "b") }))
+		parent.Register(func() string { return "c" }, WithPlugin("c"))
+
+		child := parent.Derive()
+		Expect(child.Plugins()).To(Equal([]string{"a", "b", "c"}))
+
+		child.Register(func() string { return "child-b" }, WithPlugin("b"))
+		child.Register(func() string { return "d" }, WithPlugin("d"), WithPlacement("<"))
+		child.DisableInherited("c")
+		Expect(child.Plugins()).To(Equal([]string{"d", "a", "b"}))
+		Expect(child.PluginSymbol("b")()).To(Equal("child-b"))
+		Expect(child.PluginSymbol("a")()).To(Equal("a"))
+		Expect(child.PluginSymbol("c")).To(BeNil())
+
+		Expect(parent.Plugins()).To(Equal([]string{"a", "b", "c"}))
+		Expect(parent.PluginSymbol("b")()).To(Equal("b"))
+
+		parent.Register(func() string { return "e" }, WithPlugin("e"))
+		Expect(child.Plugins()).To(Equal([]string{"d", "a", "b", "e"}))
+		Expect(child.PluginSymbol("e")()).To(Equal("e"))
+
+		child.Clear()
+		Expect(finalized).To(BeEmpty())
+		Expect(child.Plugins()).To(Equal([]string{"a", "b", "e"}))
+		Expect(parent.Plugins()).To(Equal([]string{"a", "b", "c", "e"}))
+	})
+
+	It("rejects disabling inherited plugins without parent", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "x" }, WithPlugin("x"))
+		Expect(func() { g.DisableInherited("x") }).To(PanicWith(MatchError(
+			"group plugger.fooFn has no parent group to disable inherited plugins of")))
+		Expect(g.Plugins()).To(Equal([]string{"x"}))
+	})
+
+	It("inherits exposing experimental plugins", func() {
+		parent := &PluginGroup[fooFn]{}
+		parent.Register(func() string { return "x" }, WithPlugin("x"), WithExperimental())
+		parent.EnableExperimental(true)
+		child := parent.Derive()
+		Expect(child.Plugins()).To(Equal([]string{"x"}))
+	})
+
+	It("commits registrations overriding inherited plugins", func() {
+		parent := &PluginGroup[fooFn]{}
+		parent.Register(func() string { return "a" }, WithPlugin("a"))
+		child := parent.Derive()
+		Expect(child.Plugins()).To(Equal([]string{"a"}))
+
+		r := child.Transaction()
+		r.Register(func() string { return "child-a" }, WithPlugin("a"))
+		Expect(r.Commit()).To(Succeed())
+		Expect(child.PluginSymbol("a")()).To(Equal("child-a"))
+		Expect(parent.PluginSymbol("a")()).To(Equal("a"))
+	})
+
+	It("checks IDs against inherited plugins the same when registering and committing", func() {
+		parent := &PluginGroup[fooFn]{}
+		parent.Register(func() string { return "a" }, WithPlugin("a"), WithID(1))
+		child := parent.Derive()
+		Expect(child.Plugins()).To(Equal([]string{"a"}))
+
+		Expect(func() {
+			child.Register(func() string { return "b" }, WithPlugin("b"), WithID(1))
+		}).To(PanicWith(MatchError(ErrDuplicateSymbol)))
+		r := child.Transaction()
+		r.Register(func() string { return "b" }, WithPlugin("b"), WithID(1))
+		Expect(r.Commit()).To(MatchError(ErrDuplicateSymbol))
+		Expect(child.Plugins()).To(Equal([]string{"a"}))
+
+		child.Register(func() string { return "child-a" }, WithPlugin("a"), WithID(1))
+		r = child.Transaction()
+		r.Register(func() string { return "c" }, WithPlugin("c"), WithID(2))
+		Expect(r.Commit()).To(Succeed())
+		Expect(child.Plugins()).To(Equal([]string{"a", "c"}))
+		Expect(child.PluginSymbol("a")()).To(Equal("child-a"))
+	})
+
+})
@@ -0,0 +1,82 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// Dispatcher looks up the symbols of a [PluginGroup] by a domain-specific key,
+// such as the file extension handled by a plugin, instead of by plugin name.
+// The keys of the symbols are determined by a key function and then cached,
+// until the underlying plugin group changes. If multiple symbols share the
+// same key, the symbol of the first plugin in the group's order wins. Use
+// [NewDispatcher] to create a new Dispatcher.
+type Dispatcher[K comparable, T any] struct {
+	g   *PluginGroup[T]
+	key func(T) K
+
+	mu       sync.Mutex // protects the following elements.
+	valid    bool       // is the dispatch map valid at all?
+	version  uint64     // version of the plugin group the dispatch map was built from.
+	dispatch map[K]T    // cached mapping of keys to symbols.
+}
+
+// NewDispatcher returns a new [Dispatcher] for the specified plugin group,
+// using the specified key function to determine the key of each symbol.
+func NewDispatcher[K comparable, T any](g *PluginGroup[T], key func(T) K) *Dispatcher[K, T] {
+	return &Dispatcher[K, T]{g: g, key: key}
+}
+
+// Lookup returns the symbol for the specified key, and true if found.
+// Otherwise, it returns the zero value of T and false.
+func (d *Dispatcher[K, T]) Lookup(key K) (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if version := d.g.currentVersion(); !d.valid || version != d.version {
+		// Call the key function without holding the plugin group's lock, so
+		// it can safely access the plugin group.
+		version, symbols := d.g.versionedSymbols()
+		dispatch := make(map[K]T, len(symbols))
+		for _, symbol := range symbols {
+			k := d.key(symbol.S)
+			if _, ok := dispatch[k]; ok {
+				continue
+			}
+			dispatch[k] = symbol.S
+		}
+		d.dispatch, d.version, d.valid = dispatch, version, true
+	}
+	symbol, ok := d.dispatch[key]
+	return symbol, ok
+}
+
+// currentVersion returns the version of the ordered symbols of this plugin
+// group, which changes whenever the ordered symbols change.
+func (g *PluginGroup[T]) currentVersion() uint64 {
+	g.lock()
+	defer g.unlock()
+	return g.version
+}
+
+// versionedSymbols returns a copy of the ordered symbols of this plugin group
+// together with their version.
+func (g *PluginGroup[T]) versionedSymbols() (uint64, []Symbol[T]) {
+	g.lock()
+	defer g.unlock()
+	return g.version, materialize(slices.Clone(g.symbols))
+}
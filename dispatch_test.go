@@ -0,0 +1,56 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dispatcher", func() {
+
+	It("dispatches by key and follows group changes", func() {
+		g := &PluginGroup[fooIf]{}
+		calls := 0
+		d := NewDispatcher(g, func(sym fooIf) string {
+			calls++
+			return sym.Foo()
+		})
+		_, ok := d.Lookup(".csv")
+		Expect(ok).To(BeFalse())
+
+		g.Register(&fooImpl{s: ".csv"}, WithPlugin("csv"))
+		g.Register(&fooImpl{s: ".json"}, WithPlugin("json"))
+		g.Register(&fooImpl{s: ".json"}, WithPlugin("json-too"))
+		sym, ok := d.Lookup(".csv")
+		Expect(ok).To(BeTrue())
+		Expect(sym.Foo()).To(Equal(".csv"))
+		Expect(calls).To(Equal(3))
+		sym, ok = d.Lookup(".json")
+		Expect(ok).To(BeTrue())
+		Expect(sym).To(BeIdenticalTo(g.Symbols()[1]))
+		Expect(calls).To(Equal(3))
+
+		Expect(g.Swap("json", "json-too")).To(BeTrue())
+		sym, _ = d.Lookup(".json")
+		Expect(sym).To(BeIdenticalTo(g.Symbols()[1]))
+		Expect(calls).To(Equal(6))
+
+		Expect(g.RemoveMatching(func(name string) bool { return name == "csv" })).To(Equal(1))
+		_, ok = d.Lookup(".csv")
+		Expect(ok).To(BeFalse())
+	})
+
+})
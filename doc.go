@@ -57,6 +57,13 @@ Please note that plugger defaults to deriving the plugin name from the package
 name where [plugger.PluginGroup.Register] is called. The plugin name can also be
 explicitly specifyed by using [WithPlugin] in a registration.
 
+Plugin names must be unique within a plugin group: registering a symbol for a
+plugin name already registered with the same group panics with an error
+wrapping [ErrDuplicatePlugin], reporting the source locations of both
+registrations. Please note that this is a breaking change, as earlier versions
+silently accepted multiple symbols for the same plugin name, such as when two
+plugin packages happened to be located in directories of the same name.
+
 Finally, when an application wants to invoke the registered symbols, it needs to
 grab the group object for the specific symbol type as before and then range over
 the group's exposed [plugger.PluginGroup.Symbols].
@@ -103,6 +110,13 @@ them could be removed; this v1/v2 feature wasn't really used anyway.
 Sometimes, unit tests need a well-defined isolated plugin group configuration.
 For this, [PluginGroup] objects returned by [Group]() can now be backed up and
 restored using [PluginGroup.Backup] and [PluginGroup.Restore]. Additionally,
-[PluginGroup.Clear] resets a plugin group to its initial empty state.
+[PluginGroup.ClearQuiet] quickly removes all symbols from a plugin group, without
+running any finalizers and without notifying watchers, which is what unit tests
+usually want. In contrast, [PluginGroup.Clear] properly cleans up, running the
+finalizers of all removed symbols and notifying watchers about each removed
+plugin, such as when hot-reloading plugins.
+
+Package [github.com/thediveo/go-plugger/v3/pluggertest] packages this isolation
+idiom, automatically restoring plugin groups when a test finishes.
 */
 package plugger
@@ -0,0 +1,39 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Dump returns a textual report of all plugin groups and their ordered
+// plugins, one line per plugin group, sorted by the groups' symbol type names.
+// Dump is the cross-group analogue to [PluginGroup.String] and is intended for
+// diagnostics, such as from panic or SIGQUIT handlers.
+func Dump() string {
+	var s strings.Builder
+	Fdump(&s)
+	return s.String()
+}
+
+// Fdump writes a textual report of all plugin groups and their ordered plugins
+// to w. See also [Dump].
+func Fdump(w io.Writer) {
+	for _, group := range allGroups() {
+		fmt.Fprintf(w, "%s (%d plugins)\n", group.String(), group.Len())
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dumping plugin groups", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+		groupsByName = map[string]any{}
+	})
+
+	It("dumps all plugin groups", func() {
+		Expect(Dump()).To(BeEmpty())
+
+		Group[fooFn]().Register(func() string { return "one" }, WithPlugin("one"))
+		Group[fooFn]().Register(func() string { return "two" }, WithPlugin("two"))
+		Group[barFn]()
+		Expect(Dump()).To(MatchRegexp(
+			`^PluginGroup\[github\.com/thediveo/go-plugger/v3\.barFn\]: \[\] \(0 plugins\)\n` +
+				`PluginGroup\[github\.com/thediveo/go-plugger/v3\.fooFn\]: \["one":.*,"two":.*\] \(2 plugins\)\n$`))
+	})
+
+})
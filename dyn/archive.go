@@ -0,0 +1,114 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dyn
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiscoverArchive extracts the plugin shared objects from the specified zip or
+// tar archive of the specified size into a temporary directory and then loads
+// them, so the plugins can register themselves. Only regular “.so” files with
+// paths local to the archive get extracted; other “.so” entries, such as
+// symbolic links or paths outside the archive, are reported as errors. The
+// temporary directory gets removed after loading the plugins. DiscoverArchive
+// returns the aggregated errors of all plugins that could either not be
+// extracted or failed to load, if any, but doesn't stop at the first failing
+// plugin. DiscoverArchive accepts the same options as [DiscoverWithOptions].
+func DiscoverArchive(r io.ReaderAt, size int64, opts ...DiscoverOption) error {
+	dir, err := os.MkdirTemp("", "plugger-archive-")
+	if err != nil {
+		return fmt.Errorf("cannot extract plugin archive: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	errs := []error{extractArchive(r, size, dir)}
+	errs = append(errs, DiscoverWithOptions(dir, true, opts...))
+	return errors.Join(errs...)
+}
+
+// extractArchive extracts the plugin shared objects from the specified zip or
+// tar archive into the specified directory, returning the aggregated errors
+// of entries failing to extract, if any.
+func extractArchive(r io.ReaderAt, size int64, dir string) error {
+	zr, err := zip.NewReader(r, size)
+	if err == nil {
+		var errs []error
+		for _, f := range zr.File {
+			errs = append(errs, extractEntry(dir, f.Name, f.Mode(), f.Open))
+		}
+		return errors.Join(errs...)
+	}
+	if !errors.Is(err, zip.ErrFormat) {
+		return fmt.Errorf("cannot read plugin archive: %w", err)
+	}
+	tr := tar.NewReader(io.NewSectionReader(r, 0, size))
+	var errs []error
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return errors.Join(errs...)
+		}
+		if err != nil {
+			return errors.Join(append(errs,
+				fmt.Errorf("cannot read plugin archive: %w", err))...)
+		}
+		errs = append(errs, extractEntry(dir, hdr.Name, hdr.FileInfo().Mode(),
+			func() (io.ReadCloser, error) { return io.NopCloser(tr), nil }))
+	}
+}
+
+// extractEntry extracts a single archive entry into the specified directory if
+// it is a plugin shared object, guarding against non-regular files and path
+// traversal.
+func extractEntry(dir string, name string, mode fs.FileMode, open func() (io.ReadCloser, error)) error {
+	if mode.IsDir() || filepath.Ext(name) != ".so" {
+		return nil
+	}
+	if !mode.IsRegular() {
+		return fmt.Errorf("plugin archive entry %s is not a regular file", name)
+	}
+	name = filepath.FromSlash(name)
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("plugin archive entry %s is outside the archive", name)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cannot extract plugin archive entry %s: %w", name, err)
+	}
+	src, err := open()
+	if err != nil {
+		return fmt.Errorf("cannot extract plugin archive entry %s: %w", name, err)
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o700)
+	if err != nil {
+		return fmt.Errorf("cannot extract plugin archive entry %s: %w", name, err)
+	}
+	_, err = io.Copy(dst, src)
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot extract plugin archive entry %s: %w", name, err)
+	}
+	return nil
+}
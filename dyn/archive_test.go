@@ -0,0 +1,96 @@
+//go:build plugger_dynamic
+
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dyn
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin archives", func() {
+
+	var loaded []string
+	var dirs []string
+
+	BeforeEach(func() {
+		loaded, dirs = nil, nil
+		oldPluginOpen := pluginOpen
+		DeferCleanup(func() { pluginOpen = oldPluginOpen })
+		pluginOpen = func(path string) error {
+			content, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			loaded = append(loaded, filepath.Base(path)+":"+string(content))
+			dirs = append(dirs, filepath.Dir(path))
+			return nil
+		}
+	})
+
+	It("loads plugins from a zip archive", func() {
+		var buff bytes.Buffer
+		zw := zip.NewWriter(&buff)
+		for _, name := range []string{"plugins/foo.so", "../evil.so", "README.md", "plugins/"} {
+			w, err := zw.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+			_, _ = w.Write([]byte(name))
+		}
+		hdr := &zip.FileHeader{Name: "link.so"}
+		hdr.SetMode(os.ModeSymlink | 0o777)
+		w, err := zw.CreateHeader(hdr)
+		Expect(err).NotTo(HaveOccurred())
+		_, _ = w.Write([]byte("/etc/passwd"))
+		Expect(zw.Close()).To(Succeed())
+
+		err = DiscoverArchive(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+		Expect(err).To(MatchError(ContainSubstring("plugin archive entry ../evil.so is outside the archive")))
+		Expect(err).To(MatchError(ContainSubstring("plugin archive entry link.so is not a regular file")))
+		Expect(loaded).To(ConsistOf("foo.so:plugins/foo.so"))
+		Expect(dirs[0]).NotTo(BeADirectory())
+	})
+
+	It("loads plugins from a tar archive", func() {
+		var buff bytes.Buffer
+		tw := tar.NewWriter(&buff)
+		for _, name := range []string{"foo.so", "bar/baz.so"} {
+			Expect(tw.WriteHeader(&tar.Header{
+				Name: name, Mode: 0o600, Size: int64(len(name)), Typeflag: tar.TypeReg,
+			})).To(Succeed())
+			_, _ = tw.Write([]byte(name))
+		}
+		Expect(tw.WriteHeader(&tar.Header{
+			Name: "link.so", Linkname: "foo.so", Typeflag: tar.TypeSymlink,
+		})).To(Succeed())
+		Expect(tw.Close()).To(Succeed())
+
+		Expect(DiscoverArchive(bytes.NewReader(buff.Bytes()), int64(buff.Len()))).To(
+			MatchError("plugin archive entry link.so is not a regular file"))
+		Expect(loaded).To(ConsistOf("foo.so:foo.so", "baz.so:bar/baz.so"))
+	})
+
+	It("rejects garbage", func() {
+		garbage := bytes.Repeat([]byte("D'OH!"), 200)
+		Expect(DiscoverArchive(bytes.NewReader(garbage), int64(len(garbage)))).To(
+			MatchError(ContainSubstring("cannot read plugin archive")))
+		Expect(loaded).To(BeEmpty())
+	})
+
+})
@@ -15,21 +15,202 @@
 package dyn
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
-	"plugin"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/thediveo/go-plugger/v3"
 )
 
 // Discover discovers plugins located at or within a specific path, optionally
 // also (recursively) looking into subdirectories of path, and loads them, so
-// the plugins can register themselves.
+// the plugins can register themselves. Discover stops at the first plugin
+// failing to load. Discover panics when built without the “plugger_dynamic”
+// build tag and encountering any plugin to be loaded dynamically.
 func Discover(path string, recursive bool) {
+	mustBeEnabled(DiscoverWithOptions(path, recursive))
+}
+
+// DiscoverWithProgress works like [Discover], but additionally reports the
+// discovery progress to the specified callback, if not nil. For each plugin
+// shared object, the callback first receives a [DiscoverFound] event, followed
+// by a [DiscoverLoading] event, and finally either a [DiscoverLoaded] or a
+// [DiscoverFailed] event.
+func DiscoverWithProgress(path string, recursive bool, onEvent func(DiscoverEvent)) {
+	mustBeEnabled(DiscoverWithOptions(path, recursive, WithProgress(onEvent)))
+}
+
+// mustBeEnabled panics if the specified discovery error indicates that
+// dynamically loading plugins has been disabled at build time, keeping the
+// behavior of [Discover] that doesn't return errors.
+func mustBeEnabled(err error) {
+	if errors.Is(err, ErrDynamicDisabled) {
+		panic(err)
+	}
+}
+
+// DiscoverWithOptions works like [Discover], but additionally accepts options
+// and returns the errors of plugins that failed to load, if any. Unless
+// [WithContinueOnError] is specified, the first plugin failing to load stops
+// the discovery of further plugins. When built without the “plugger_dynamic”
+// build tag, DiscoverWithOptions returns [ErrDynamicDisabled] as soon as it
+// encounters any plugin to be loaded dynamically, instead of panicking.
+func DiscoverWithOptions(path string, recursive bool, opts ...DiscoverOption) error {
+	d := &discovery{recursive: recursive, start: time.Now()}
+	for _, opt := range opts {
+		opt(d)
+	}
 	// We handle also the non-recursive usecase with the ordinary filepath
 	// walker, as this simplifies things enormously ... when combined with
 	// closures.
 	_ = filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-		return walkedOnSomething(recursive, path, info, err)
+		return walkedOnSomething(d, path, info, err)
 	})
+	return errors.Join(d.errs...)
+}
+
+// DiscoverOption allows optional discovery behavior to be specified for
+// [DiscoverWithOptions].
+type DiscoverOption func(*discovery)
+
+// WithProgress reports the discovery progress to the specified callback, if
+// not nil. See also [DiscoverWithProgress].
+func WithProgress(onEvent func(DiscoverEvent)) DiscoverOption {
+	return func(d *discovery) {
+		d.onEvent = onEvent
+	}
+}
+
+// WithContinueOnError enables or disables continuing the discovery of further
+// plugins after a plugin failed to load; by default, discovery stops at the
+// first failing plugin. [DiscoverWithOptions] then returns the aggregated errors
+// of all plugins that failed to load. Discovery always stops when loading
+// plugins dynamically has been disabled at build time.
+func WithContinueOnError(continueOnError bool) DiscoverOption {
+	return func(d *discovery) {
+		d.continueOnError = continueOnError
+	}
+}
+
+// WithRecoverInit enables or disables recovering from panics while loading
+// plugins, such as panics in the init functions of plugins. Recovered panics
+// are reported as errors instead, so that a single malformed plugin cannot
+// crash its host during discovery. Please note that a plugin that panicked
+// while loading must not be loaded again, as this will block forever.
+func WithRecoverInit(recoverInit bool) DiscoverOption {
+	return func(d *discovery) {
+		d.recoverInit = recoverInit
+	}
+}
+
+// WithRegistrationCheck enables reporting loaded plugins that didn't register
+// any symbols with the specified plugin groups as errors, such as plugins built
+// against a stale API that register with groups not matching anymore. If no
+// groups are specified, the symbols registered with all plugin groups count,
+// including the plugin groups created while loading plugins. Please note that
+// loading an already loaded plugin doesn't register any symbols again.
+func WithRegistrationCheck(groups ...plugger.AnyGroup) DiscoverOption {
+	return func(d *discovery) {
+		d.checkRegistrations = true
+		d.groups = groups
+	}
+}
+
+// DiscoverEventKind identifies the stage of discovering and loading a
+// particular plugin shared object.
+type DiscoverEventKind int
+
+// The stages of discovering and loading a plugin shared object.
+const (
+	DiscoverFound   DiscoverEventKind = iota // found a plugin shared object.
+	DiscoverLoading                          // starting to load a plugin shared object.
+	DiscoverLoaded                           // successfully loaded a plugin shared object.
+	DiscoverFailed                           // failed to load a plugin shared object.
+)
+
+// String returns the name of the event kind, such as "found".
+func (k DiscoverEventKind) String() string {
+	switch k {
+	case DiscoverFound:
+		return "found"
+	case DiscoverLoading:
+		return "loading"
+	case DiscoverLoaded:
+		return "loaded"
+	case DiscoverFailed:
+		return "failed"
+	}
+	return "DiscoverEventKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// DiscoverEvent reports the progress of discovering and loading a particular
+// plugin shared object.
+type DiscoverEvent struct {
+	Kind     DiscoverEventKind
+	Path     string        // path of the plugin shared object.
+	Elapsed  time.Duration // time elapsed since the discovery started.
+	Duration time.Duration // loading duration, for loaded and failed events only.
+	Err      error         // loading error, for failed events only.
+}
+
+// discovery represents the state and options of a single plugin discovery.
+type discovery struct {
+	recursive       bool                // recursively discover plugins in subdirectories?
+	recoverInit     bool                // recover from panics while loading plugins?
+	continueOnError bool                // continue discovery after a plugin failed to load?
+	start           time.Time           // start of this discovery.
+	onEvent         func(DiscoverEvent) // optional progress callback.
+	errs            []error             // errors of plugins failing to load.
+
+	checkRegistrations bool               // report plugins not registering any symbols?
+	groups             []plugger.AnyGroup // optional plugin groups to check for registrations.
+}
+
+// registrations returns the number of symbol registrations so far with the
+// plugin groups to check for registrations, including registrations of
+// symbols that aren't exposed.
+func (d *discovery) registrations() uint64 {
+	groups := d.groups
+	if len(groups) == 0 {
+		// As every type implements the empty interface, this gets us all
+		// plugin groups.
+		groups = plugger.GroupsImplementing(reflect.TypeFor[any]())
+	}
+	var n uint64
+	for _, group := range groups {
+		n += group.Registrations()
+	}
+	return n
+}
+
+// emit the specified event, filling in the elapsed time since the discovery
+// started.
+func (d *discovery) emit(ev DiscoverEvent) {
+	if d.onEvent == nil {
+		return
+	}
+	ev.Elapsed = time.Since(d.start)
+	d.onEvent(ev)
+}
+
+// open the plugin at the specified path, optionally recovering from panics.
+func (d *discovery) open(path string) (err error) {
+	if d.recoverInit {
+		defer func() {
+			if p := recover(); p != nil {
+				if perr, ok := p.(error); ok {
+					err = fmt.Errorf("plugin %s panicked while loading: %w", path, perr)
+					return
+				}
+				err = fmt.Errorf("plugin %s panicked while loading: %v", path, p)
+			}
+		}()
+	}
+	return pluginOpen(path)
 }
 
 // pluginOpen is only, erm, plugged in by a wrapper calling plugin.Open instead
@@ -38,29 +219,66 @@ func Discover(path string, recursive bool) {
 // plugin loading required; otherwise the Go linker will complain as soon as the
 // plug.Open symbol is being present (even if not used at all) and a static
 // binary is to be build.
-var pluginOpen = func(path string) error {
-	panic("dynamically loading plugins disabled; build with -tags plugger_dynamic")
+var pluginOpen = dynamicDisabled
+
+// dynamicDisabled reports that dynamically loading plugins has been disabled at
+// build time.
+func dynamicDisabled(path string) error {
+	return fmt.Errorf("cannot load plugin %s: %w; build with -tags plugger_dynamic",
+		path, ErrDynamicDisabled)
 }
 
+// ErrDynamicDisabled reports attempting to load dynamic plugins in a binary
+// built without dynamic plugin loading support.
+var ErrDynamicDisabled = errors.New("dynamically loading plugins disabled")
+
 // This is an example of when to separate out an enclosed callback function in
 // order to allow testing it separately.
-func walkedOnSomething(recursive bool, path string, info os.FileInfo, err error) error {
+func walkedOnSomething(d *discovery, path string, info os.FileInfo, err error) error {
 	if info != nil {
 		if info.IsDir() {
 			// If its a directory and we're not allowed to search
 			// recursively for plugins, then tell the walker to please
 			// stop here and to go elsewhere. Otherwise, let the walker
 			// walk freely.
-			if !recursive {
+			if !d.recursive {
 				return filepath.SkipDir
 			}
 		} else if filepath.Ext(info.Name()) == ".so" {
 			// If it's a file and its name looks like a potential shared
-			// library, then try to load it. If it fails, we keep silent,
-			// because we want to look still for other plugins. Please note
-			// that the loaded plugin is responsible to register itself.
-			_, err = plugin.Open(path)
+			// library, then try to load it. If it fails, we remember the
+			// error for reporting and stop, unless told to look still for
+			// other plugins. Please note that the loaded plugin is
+			// responsible to register itself.
+			d.emit(DiscoverEvent{Kind: DiscoverFound, Path: path})
+			d.emit(DiscoverEvent{Kind: DiscoverLoading, Path: path})
+			var registered uint64
+			if d.checkRegistrations {
+				registered = d.registrations()
+			}
+			loadStart := time.Now()
+			if err := d.open(path); err != nil {
+				d.emit(DiscoverEvent{Kind: DiscoverFailed, Path: path,
+					Duration: time.Since(loadStart), Err: err})
+				return d.failed(err)
+			}
+			d.emit(DiscoverEvent{Kind: DiscoverLoaded, Path: path,
+				Duration: time.Since(loadStart)})
+			if d.checkRegistrations && d.registrations() == registered {
+				return d.failed(fmt.Errorf("plugin %s didn't register any symbols", path))
+			}
 		}
 	}
 	return err
 }
+
+// failed records the specified error of a plugin failing to load and tells the
+// walker to stop, unless continuing on errors and loading plugins is possible
+// at all.
+func (d *discovery) failed(err error) error {
+	d.errs = append(d.errs, err)
+	if d.continueOnError && !errors.Is(err, ErrDynamicDisabled) {
+		return nil
+	}
+	return filepath.SkipAll
+}
@@ -17,6 +17,8 @@
 package dyn
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -59,25 +61,186 @@ var _ = Describe("dynamic plugin", func() {
 
 	})
 
+	Describe("discovery progress", func() {
+
+		It("reports discovery progress", func() {
+			var events []DiscoverEvent
+			DiscoverWithProgress("../example", true, func(ev DiscoverEvent) {
+				events = append(events, ev)
+			})
+			Expect(events).To(HaveExactElements(
+				HaveField("Kind", DiscoverFound),
+				HaveField("Kind", DiscoverLoading),
+				And(HaveField("Kind", DiscoverLoaded),
+					HaveField("Path", "../example/dynplug/dynplug.so"),
+					HaveField("Err", BeNil())),
+			))
+		})
+
+		It("reports failed loads", func() {
+			var events []DiscoverEvent
+			d := &discovery{start: time.Now(), onEvent: func(ev DiscoverEvent) {
+				events = append(events, ev)
+			}}
+			Expect(walkedOnSomething(d,
+				"../example/plugin/plugin.go",
+				mockedFileInfo{name: "plugin.so", isdir: false},
+				nil)).To(Equal(filepath.SkipAll))
+			Expect(d.errs).To(ConsistOf(HaveOccurred()))
+			Expect(events).To(HaveExactElements(
+				HaveField("Kind", DiscoverFound),
+				HaveField("Kind", DiscoverLoading),
+				And(HaveField("Kind", DiscoverFailed), HaveField("Err", HaveOccurred())),
+			))
+			Expect(events[2].Kind.String()).To(Equal("failed"))
+		})
+
+	})
+
+	Describe("failing plugins", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "a.so"), nil, 0o644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "b.so"), nil, 0o644)).To(Succeed())
+			oldPluginOpen := pluginOpen
+			DeferCleanup(func() { pluginOpen = oldPluginOpen })
+		})
+
+		It("stops at the first failing plugin by default", func() {
+			var opened []string
+			pluginOpen = func(path string) error {
+				opened = append(opened, filepath.Base(path))
+				return errors.New("D'OH!")
+			}
+			Expect(DiscoverWithOptions(dir, true)).To(MatchError("D'OH!"))
+			Expect(opened).To(Equal([]string{"a.so"}))
+		})
+
+		It("continues after failing plugins when told so", func() {
+			var opened []string
+			pluginOpen = func(path string) error {
+				opened = append(opened, filepath.Base(path))
+				return errors.New("D'OH!")
+			}
+			Expect(DiscoverWithOptions(dir, true, WithContinueOnError(true))).To(
+				MatchError("D'OH!\nD'OH!"))
+			Expect(opened).To(Equal([]string{"a.so", "b.so"}))
+		})
+
+		It("reports disabled dynamic loading instead of panicking", func() {
+			pluginOpen = dynamicDisabled
+			var err error
+			Expect(func() {
+				err = DiscoverWithOptions(dir, true, WithContinueOnError(true))
+			}).NotTo(Panic())
+			Expect(err).To(MatchError(ErrDynamicDisabled))
+			Expect(err).To(MatchError(MatchRegexp(`^cannot load plugin .*/a\.so: `)))
+			Expect(func() { Discover(dir, true) }).To(PanicWith(MatchError(ErrDynamicDisabled)))
+		})
+
+	})
+
+	Describe("recovering from panicking plugins", func() {
+
+		BeforeEach(func() {
+			oldPluginOpen := pluginOpen
+			DeferCleanup(func() { pluginOpen = oldPluginOpen })
+			pluginOpen = func(path string) error { panic("D'OH!") }
+		})
+
+		It("aggregates recovered panics into errors", func() {
+			Expect(DiscoverWithOptions("../example", true, WithRecoverInit(true))).To(
+				MatchError("plugin ../example/dynplug/dynplug.so panicked while loading: D'OH!"))
+		})
+
+		It("keeps recovered errors", func() {
+			errDoh := errors.New("D'OH!")
+			pluginOpen = func(path string) error {
+				panic(fmt.Errorf("plugin init: %w", errDoh))
+			}
+			Expect(DiscoverWithOptions("../example", true, WithRecoverInit(true))).To(
+				MatchError(errDoh))
+		})
+
+		It("doesn't recover unless told so", func() {
+			Expect(func() { _ = DiscoverWithOptions("../example", true) }).To(PanicWith("D'OH!"))
+		})
+
+	})
+
+	Describe("checking registrations", func() {
+
+		BeforeEach(func() {
+			oldPluginOpen := pluginOpen
+			DeferCleanup(func() { pluginOpen = oldPluginOpen })
+			pluginOpen = func(path string) error { return nil }
+		})
+
+		It("reports plugins not registering any symbols", func() {
+			Expect(DiscoverWithOptions("../example", true)).To(Succeed())
+			Expect(DiscoverWithOptions("../example", true, WithRegistrationCheck())).To(
+				MatchError("plugin ../example/dynplug/dynplug.so didn't register any symbols"))
+			Expect(DiscoverWithOptions("../example", true,
+				WithRegistrationCheck(plugger.Group[plugin.DoItFn]()))).To(HaveOccurred())
+		})
+
+		It("accepts plugins registering symbols", func() {
+			type checkFn func()
+			g := plugger.Group[checkFn]()
+			DeferCleanup(func() { g.Clear() })
+			pluginOpen = func(path string) error {
+				g.Register(func() {}, plugger.WithPlugin(path))
+				return nil
+			}
+			Expect(DiscoverWithOptions("../example", true, WithRegistrationCheck())).To(Succeed())
+			g.Clear()
+			Expect(DiscoverWithOptions("../example", true, WithRegistrationCheck(g))).To(Succeed())
+		})
+
+		It("accepts plugins registering only hidden symbols", func() {
+			type checkFn func()
+			g := plugger.Group[checkFn]()
+			DeferCleanup(func() { g.Clear() })
+			g.Register(func() {}, plugger.WithPlugin("regular"))
+			pluginOpen = func(path string) error {
+				g.Register(func() {}, plugger.WithPlugin(path), plugger.WithExperimental())
+				return nil
+			}
+			Expect(DiscoverWithOptions("../example", true, WithRegistrationCheck(g))).To(Succeed())
+			Expect(g.Len()).To(Equal(1))
+
+			pluginOpen = func(path string) error {
+				g.RegisterDefault(func() {}, plugger.WithPlugin(path+"-default"))
+				return nil
+			}
+			Expect(DiscoverWithOptions("../example", true, WithRegistrationCheck(g))).To(Succeed())
+			Expect(g.Len()).To(Equal(1))
+		})
+
+	})
+
 	Describe("plugin walking", func() {
 
 		It("walks an existing plugin .so", func() {
 			Expect(walkedOnSomething(
-				false, "../example/dynplug/dynplug.so",
+				&discovery{}, "../example/dynplug/dynplug.so",
 				mockedFileInfo{name: "dynplug.so", isdir: false},
 				nil)).To(Succeed())
 		})
 
 		It("skips something else than .so", func() {
 			Expect(walkedOnSomething(
-				false, "plugins/foo/foo.bar",
+				&discovery{}, "plugins/foo/foo.bar",
 				mockedFileInfo{name: "foo.bar", isdir: false},
 				nil)).To(Succeed())
 		})
 
 		It("wants to walk into sub directories", func() {
 			Expect(walkedOnSomething(
-				false, "plugins/foo",
+				&discovery{}, "plugins/foo",
 				mockedFileInfo{name: "foo", isdir: true},
 				nil)).To(Equal(filepath.SkipDir))
 		})
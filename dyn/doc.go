@@ -6,6 +6,14 @@ plugins then can register themselves with the plugger plugin mechanism.
 
 The build tag/constraint “plugger_dynamic” must have been specified when using
 this package; otherwise, [Discover] will panic as soon as it encounters any
-plugin to be loaded dynamically.
+plugin to be loaded dynamically. In contrast, [DiscoverWithOptions] then returns
+[ErrDynamicDisabled] instead.
+
+# Failing Plugins
+
+Discovery stops at the first plugin failing to load; use [WithContinueOnError]
+to discover and load further plugins nevertheless. Except for dynamic loading
+being disabled, [Discover] and [DiscoverWithProgress] don't report plugins
+failing to load; use [DiscoverWithOptions] to learn about them.
 */
 package dyn
@@ -0,0 +1,80 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the failures reported by this package, either as the
+// values of panics or as (wrapped) errors returned by the error-returning
+// variants. Use [errors.Is] to check for them, such as after recovering from a
+// panic.
+var (
+	// ErrNilSymbol reports a nil func or interface symbol.
+	ErrNilSymbol = errors.New("symbol must not be nil")
+	// ErrInvalidSymbolType reports a symbol type that is neither a func nor
+	// an interface type, or a symbol not assignable to a plugin group's
+	// symbol type.
+	ErrInvalidSymbolType = errors.New("invalid symbol type")
+	// ErrDuplicatePlugin reports a plugin name that is already in use.
+	ErrDuplicatePlugin = errors.New("duplicate plugin")
+	// ErrDuplicateSymbol reports a symbol whose stable numeric ID, as set
+	// using [WithID], is already in use by the symbol of another plugin.
+	ErrDuplicateSymbol = errors.New("duplicate symbol ID")
+	// ErrDuplicateID is an alias of [ErrDuplicateSymbol].
+	ErrDuplicateID = ErrDuplicateSymbol
+	// ErrCallerUnknown reports failing to discover the registering caller.
+	ErrCallerUnknown = errors.New("unable to discover caller")
+	// ErrNoPluginName reports failing to derive a plugin name from the
+	// registering caller's location.
+	ErrNoPluginName = errors.New("cannot determine plugin name")
+	// ErrNotInterface reports a type that must be, but isn't, an interface
+	// type.
+	ErrNotInterface = errors.New("type must be interface")
+	// ErrNotImplemented reports a symbol not implementing a required
+	// interface type.
+	ErrNotImplemented = errors.New("symbol must implement")
+	// ErrGroupKeyCollision reports distinct symbol types sharing the same
+	// name-based group key.
+	ErrGroupKeyCollision = errors.New("name-based group key collides")
+	// ErrNoGroup reports a missing plugin group for a symbol type.
+	ErrNoGroup = errors.New("no plugin group")
+	// ErrNoParent reports a plugin group that isn't derived from a parent
+	// group, but needs to be.
+	ErrNoParent = errors.New("no parent group")
+	// ErrFrozen reports registering with a frozen plugin group.
+	ErrFrozen = errors.New("frozen")
+	// ErrExplicitNameRequired reports registering a symbol without an
+	// explicit plugin name with a plugin group requiring explicit names.
+	ErrExplicitNameRequired = errors.New("explicit plugin name required")
+	// ErrRejected reports a symbol rejected by a plugin group's validator;
+	// the validator's error is wrapped as well.
+	ErrRejected = errors.New("symbol rejected by validator")
+	// ErrUnresolvedPlacement reports placement hints referencing unknown
+	// plugins in strict placement mode.
+	ErrUnresolvedPlacement = errors.New("unresolved placements")
+)
+
+// panicError returns the specified recovered panic value as an error, keeping
+// the original error if the panic value is an error so that it can still be
+// checked using [errors.Is].
+func panicError(p any) error {
+	if err, ok := p.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", p)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sentinel errors", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+	})
+
+	It("panics with sentinel errors", func() {
+		recovered := func(fn func()) (err error) {
+			defer func() { err = panicError(recover()) }()
+			fn()
+			return nil
+		}
+		g := Group[fooFn]()
+		Expect(errors.Is(recovered(func() { g.Register(nil) }), ErrNilSymbol)).To(BeTrue())
+		Expect(recovered(func() { Group[int]().RegisterFactory("int", func() int { return 42 }) })).To(
+			MatchError(ErrInvalidSymbolType))
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+		Expect(recovered(func() {
+			g.Register(func() string { return "two" }, WithPlugin("two"), WithID(1))
+		})).To(MatchError(ErrDuplicateSymbol))
+		Expect(recovered(func() {
+			g.Register(func() string { return "one" }, WithPlugin("one"))
+		})).To(MatchError(ErrDuplicatePlugin))
+		Expect(recovered(func() {
+			(&Symbol[fooFn]{}).complete(0, func(int) (uintptr, string, int, bool) { return 0, "", 0, false })
+		})).To(MatchError(ErrCallerUnknown))
+		Expect(recovered(func() {
+			(&Symbol[fooFn]{}).complete(0, func(int) (uintptr, string, int, bool) { return 0, "foo.go", 0, true })
+		})).To(MatchError(ErrNoPluginName))
+		Expect(recovered(func() { GroupsImplementing(reflect.TypeFor[fooFn]()) })).To(MatchError(ErrNotInterface))
+		Expect(recovered(func() { RegisterAs[*fooImpl](Group[any](), &fooImpl{}) })).To(MatchError(ErrNotInterface))
+		Expect(recovered(func() { RegisterAssert[fooIf, fmt.Stringer](Group[fooIf](), &fooImpl{}) })).To(
+			MatchError(ErrNotImplemented))
+		Expect(recovered(func() { g.DisableInherited("one") })).To(MatchError(ErrNoParent))
+
+		validated := &PluginGroup[fooFn]{}
+		validated.SetValidator(func(fooFn) error { return errors.New("nope") })
+		err := recovered(func() { validated.Register(func() string { return "x" }, WithPlugin("x")) })
+		Expect(err).To(MatchError(ErrRejected))
+		Expect(err).To(MatchError("symbol rejected by validator: nope"))
+
+		explicit := &PluginGroup[fooFn]{}
+		explicit.RequireExplicitName(true)
+		Expect(recovered(func() { explicit.Register(func() string { return "x" }) })).To(
+			MatchError(ErrExplicitNameRequired))
+		tx := explicit.Transaction()
+		tx.Register(func() string { return "x" })
+		Expect(tx.Commit()).To(MatchError(ErrExplicitNameRequired))
+
+		strict := &PluginGroup[fooFn]{}
+		strict.SetStrictPlacement(true)
+		strict.Register(func() string { return "x" }, WithPlugin("x"), WithPlacement("<nope"))
+		Expect(recovered(func() { strict.Plugins() })).To(MatchError(ErrUnresolvedPlacement))
+
+		frozen := &PluginGroup[fooFn]{}
+		frozen.Freeze()
+		Expect(recovered(func() { frozen.Register(func() string { return "x" }, WithPlugin("x")) })).To(
+			MatchError(ErrFrozen))
+		Expect(frozen.Merge(g)).To(MatchError(ErrFrozen))
+	})
+
+	It("returns sentinel errors", func() {
+		g := Group[fooFn]()
+		Expect(g.RegisterAny(fooFn(nil))).To(MatchError(ErrNilSymbol))
+		Expect(g.RegisterAny(42)).To(MatchError(ErrInvalidSymbolType))
+		Expect(RegisterReflect(reflect.TypeFor[barFn](), barFn(nil))).To(MatchError(ErrNoGroup))
+		Expect(g.RegisterWrapping("one", func(fooFn) fooFn { return nil })).To(
+			MatchError(ContainSubstring("unknown plugin")))
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		Expect(g.RegisterWrapping("one", func(fooFn) fooFn { return nil })).To(MatchError(ErrNilSymbol))
+		Expect(g.ImportOrder([]string{"one", "one"})).To(MatchError(ErrDuplicatePlugin))
+
+		tx := g.Transaction()
+		tx.RegisterNamed("one", func() string { return "one" })
+		Expect(tx.Commit()).To(MatchError(ErrDuplicatePlugin))
+	})
+
+})
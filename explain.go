@@ -0,0 +1,71 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+// PlacementStep describes a single move of a plugin while resolving the
+// placement hints of a set of plugins, as returned by
+// [PluginGroup.ExplainPlacement].
+type PlacementStep struct {
+	Pass      int    // placement pass, starting with 1.
+	Plugin    string // name of the moved plugin.
+	Placement string // placement hint causing the move.
+	From      int    // index of the plugin before the move.
+	To        int    // index of the plugin after the move.
+}
+
+// String returns a textual description of the placement step.
+func (s PlacementStep) String() string {
+	return fmt.Sprintf("pass %d: plugin %q moved %d→%d due to placement %q",
+		s.Pass, s.Plugin, s.From, s.To, s.Placement)
+}
+
+// ExplainPlacement returns step by step how the specified (hypothetical)
+// symbols get placed, using this plugin group's basic ordering and default
+// placement, without touching the symbols registered with this plugin group.
+// The steps start with the symbols in their basic order and are recorded for
+// all placement passes until the order stabilizes, but for not more passes
+// than there are symbols.
+func (g *PluginGroup[T]) ExplainPlacement(symbols []Symbol[T]) []PlacementStep {
+	g.mu.RLock()
+	symbols = g.placements(slices.Clone(symbols))
+	ordering, collation := g.ordering, g.collation
+	g.mu.RUnlock()
+
+	order(symbols, ordering, collation)
+	var steps []PlacementStep
+	resolved := symbols
+	for pass := 1; pass <= len(symbols)+1; pass++ {
+		next := place(symbols, slices.Clone(resolved), func(symbol Symbol[T], from, to int) {
+			steps = append(steps, PlacementStep{
+				Pass:      pass,
+				Plugin:    symbol.Plugin,
+				Placement: symbol.Placement,
+				From:      from,
+				To:        to,
+			})
+		})
+		if slices.Equal(pluginNames(next), pluginNames(resolved)) {
+			break
+		}
+		resolved = next
+	}
+	return steps
+}
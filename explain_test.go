@@ -0,0 +1,51 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("explaining placements", func() {
+
+	It("explains placement steps", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "registered" }, WithPlugin("registered"))
+		Expect(g.ExplainPlacement(nil)).To(BeEmpty())
+
+		symbols := []Symbol[fooFn]{
+			{Plugin: "c", Placement: "<a"},
+			{Plugin: "b", Placement: ">"},
+			{Plugin: "a"},
+		}
+		steps := g.ExplainPlacement(symbols)
+		Expect(steps).To(Equal([]PlacementStep{
+			{Pass: 1, Plugin: "b", Placement: ">", From: 1, To: 2},
+			{Pass: 1, Plugin: "c", Placement: "<a", From: 1, To: 0},
+		}))
+		Expect(steps[0].String()).To(Equal(`pass 1: plugin "b" moved 1→2 due to placement ">"`))
+		Expect(symbols[0].Plugin).To(Equal("c"))
+		Expect(g.Plugins()).To(Equal([]string{"registered"}))
+
+		g.SetDefaultPlacement("<")
+		Expect(g.ExplainPlacement([]Symbol[fooFn]{{Plugin: "a"}, {Plugin: "b"}})).To(Equal([]PlacementStep{
+			{Pass: 1, Plugin: "b", Placement: "<", From: 1, To: 0},
+			{Pass: 2, Plugin: "a", Placement: "<", From: 1, To: 0},
+			{Pass: 2, Plugin: "b", Placement: "<", From: 1, To: 0},
+		}))
+	})
+
+})
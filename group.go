@@ -15,12 +15,21 @@
 package plugger
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"os"
 	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/exp/slices"
 )
@@ -32,6 +41,37 @@ type PluginGroup[T any] struct {
 	mu      sync.RWMutex // protects the following elements.
 	ordered bool         // has the list of registered plugin symbols been ordered or is it still unordered?
 	symbols []Symbol[T]  // (ordered) list of registered plugin symbols.
+	hidden  []Symbol[T]  // default symbols hidden by regular symbols, and gated experimental symbols.
+
+	conflicts        chan<- error      // optional channel to report placement conflicts to.
+	defaultPlacement string            // placement hint for symbols without explicit placement.
+	registered       chan struct{}     // closed when new symbols get registered, if waited for.
+	registeredCount  uint64            // number of symbol registrations since creation.
+	strict           bool              // panic on placement hints referencing unknown plugins?
+	ordering         Ordering          // basic ordering before applying placement hints.
+	watchers         []chan GroupEvent // subscribers to registration and removal events.
+	winner           WinnerPolicy      // which symbol wins in Winner().
+	sortCheck        bool              // check sort results to be fixed points?
+	metrics          *sync.Map         // optional plugin name to *atomic.Int64 usage counters.
+	sorts            uint64            // number of times the symbols have been sorted.
+	sortTime         time.Duration     // cumulative time spent sorting the symbols.
+	explicitNames    bool              // reject plugin names derived from the caller's directory?
+	allowNil         bool              // accept typed nil interface symbols?
+	version          uint64            // incremented whenever the ordered symbols change.
+	collation        Collation         // how to compare plugin names when ordering by name.
+	firstUse         sync.Once         // logs the plugin order on first use in debug mode.
+	validator        func(T) error     // optional domain-specific symbol validation.
+	frozen           bool              // reject any further registrations?
+	parent           *PluginGroup[T]   // optional parent group to inherit symbols from.
+	parentVersion    uint64            // version of the parent group's inherited symbols.
+	disabled         map[string]bool   // names of plugins not to inherit from the parent.
+	experimental     bool              // expose experimental symbols?
+	manual           []string          // optional manual plugin order overriding placements.
+	dedupe           bool              // skip registering already registered symbol values?
+	view             []T               // cached read-only ordered symbols, if any.
+	viewVersion      uint64            // version of the cached ordered symbols.
+	warnEmpty        bool              // warn when querying before any registration?
+	emptyWarned      sync.Once         // warns only once about querying an empty group.
 }
 
 // GroupStash is a “backup” of a PluginGroup. It can be used especially in
@@ -41,6 +81,7 @@ type PluginGroup[T any] struct {
 type GroupStash[T any] struct {
 	ordered bool
 	symbols []Symbol[T]
+	hidden  []Symbol[T]
 }
 
 // Group returns the [*PluginGroup] object for the given exposed symbol type T.
@@ -51,17 +92,176 @@ func Group[T any]() *PluginGroup[T] {
 	t := reflect.TypeOf(dummyCompositeT).Elem()
 	groupsmu.Lock()
 	defer groupsmu.Unlock()
-	group := groups[t]
+	group := lookupGroup(t)
 	if group == nil {
+		warnTypeDuplicates(t)
 		group = &PluginGroup[T]{}
-		groups[t] = group
+		storeGroup(t, group)
+	}
+	g, ok := group.(*PluginGroup[T])
+	if !ok {
+		panic(fmt.Errorf("%w: %q for different types %T and %T",
+			ErrGroupKeyCollision, groupKeyName(t), group, g))
 	}
-	return group.(*PluginGroup[T])
+	return g
 }
 
 // groups maps function and interface types to their (typed) plugin groups.
+// Alternatively, groupsByName maps the type names to their (typed) plugin
+// groups when name-based group keys are in use.
 var groupsmu sync.Mutex
 var groups = map[reflect.Type]any{} // actually, *PluginGroup[T]
+var groupsByName = map[string]any{} // actually, *PluginGroup[T]
+var nameBasedGroupKeys bool
+
+// UseNameBasedGroupKeys switches between keying plugin groups by their symbol
+// types' identities (the default) and keying them by their symbol types' names
+// instead, that is, the types' package import paths and names.
+//
+// Name-based group keys support hot-reload development setups where reloading
+// a plugin shared object might produce a “new” type for the same logical
+// symbol type, which otherwise would fragment the plugin group. However,
+// name-based group keys come with the tradeoff that distinct types with the
+// same name string now map to the same plugin group: while this cannot happen
+// with named types from different packages, it happens with unnamed types,
+// such as func(string) string, that have the same textual representation.
+// [Group] panics when it detects such a collision.
+//
+// Switching the group keying affects only calls to [Group] afterwards, so
+// UseNameBasedGroupKeys should be called early, before any plugin registers.
+func UseNameBasedGroupKeys(enable bool) {
+	groupsmu.Lock()
+	defer groupsmu.Unlock()
+	nameBasedGroupKeys = enable
+}
+
+// lookupGroup returns the plugin group for the specified symbol type, or nil if
+// there is no such group yet. It must be called with groupsmu locked.
+func lookupGroup(t reflect.Type) any {
+	if nameBasedGroupKeys {
+		return groupsByName[groupKeyName(t)]
+	}
+	return groups[t]
+}
+
+// storeGroup stores the plugin group for the specified symbol type. It must be
+// called with groupsmu locked.
+func storeGroup(t reflect.Type, group any) {
+	if nameBasedGroupKeys {
+		groupsByName[groupKeyName(t)] = group
+		return
+	}
+	groups[t] = group
+}
+
+// warnTypeDuplicates logs a warning if there already is a plugin group for a
+// symbol type with the same package import path and name as the specified
+// symbol type, yet with a different type identity. This usually signals an
+// accidental type duplication, such as identically defined local types, which
+// leads to separate plugin groups unexpectedly. It must be called with
+// groupsmu locked.
+func warnTypeDuplicates(t reflect.Type) {
+	if nameBasedGroupKeys || t.Name() == "" {
+		return
+	}
+	name := groupKeyName(t)
+	for other := range groups {
+		if other != t && other.Name() != "" && groupKeyName(other) == name {
+			log.Printf("plugger: distinct symbol types %s and %s share the name %s, resulting in separate groups",
+				typeIdentity(other), typeIdentity(t), name)
+			return
+		}
+	}
+}
+
+// typeIdentity returns a textual representation of the specified type's
+// identity, consisting of the type's package import path and name, as well as
+// the address of the type's runtime representation.
+func typeIdentity(t reflect.Type) string {
+	return fmt.Sprintf("%s@%p", groupKeyName(t), t)
+}
+
+// groupKeyName returns the name-based group key for the specified symbol type.
+func groupKeyName(t reflect.Type) string {
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// StashGroups backs up and then clears the plugin groups for the specified
+// symbol types, returning a function that restores the plugin groups to their
+// original configuration. Plugin groups not yet existing when calling
+// StashGroups get cleared upon restoration, if they have come into existence
+// in the meantime. Use [reflect.TypeFor] to get the symbol types, such as
+// reflect.TypeFor[fooFn]().
+//
+// StashGroups is intended for use in unit tests that need to work with
+// multiple isolated plugin groups at the same time.
+func StashGroups(types ...reflect.Type) (restore func()) {
+	groupsmu.Lock()
+	defer groupsmu.Unlock()
+	restores := make([]func(), 0, len(types))
+	for _, t := range types {
+		t := t
+		group, ok := lookupGroup(t).(untypedGroup)
+		if !ok {
+			restores = append(restores, func() {
+				groupsmu.Lock()
+				group, ok := lookupGroup(t).(untypedGroup)
+				groupsmu.Unlock()
+				if ok {
+					group.Clear()
+				}
+			})
+			continue
+		}
+		restores = append(restores, group.stash())
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// untypedGroup is implemented by all [PluginGroup] objects, independent of
+// their particular symbol type.
+type untypedGroup interface {
+	AnyGroup
+	Clear()
+	Freeze()
+	stash() (restore func())
+	registerAny(symbol any, opts []RegisterOption, offset int) error
+	hasPlugin(name string) bool
+	describe() GroupDescription
+}
+
+var _ untypedGroup = (*PluginGroup[any])(nil)
+
+// stash backs up and then clears this plugin group, returning a function that
+// restores the plugin group to its original configuration.
+func (g *PluginGroup[T]) stash() (restore func()) {
+	backup := g.Backup()
+	g.Restore(GroupStash[T]{})
+	return func() { g.Restore(backup) }
+}
+
+// TypeIdentity returns a textual representation of the identity of this
+// group's symbol type, for debugging why symbols end up in separate plugin
+// groups. The identity consists of the package import path and name of the
+// symbol type, followed by the address of the type's runtime representation.
+//
+// As plugin groups are keyed by type identity (unless using name-based group
+// keys), distinct named types always result in distinct plugin groups, even if
+// they have the same underlying type: for instance, a named type “type fooFn
+// func() string” and its underlying func() string get separate groups. The
+// same applies to identically defined types that are local to different
+// functions; as these share the same package import path and name, [Group]
+// logs a warning when creating such a separate group.
+func (g *PluginGroup[T]) TypeIdentity() string {
+	return typeIdentity(g.SymbolType())
+}
 
 // String renders a textual representation of a particular Group, showing the
 // managed symbol type as well as the plugin-exposed symbols registered in this
@@ -85,33 +285,265 @@ func (g *PluginGroup[T]) String() string {
 		s.WriteRune('"')
 		s.WriteString(symbol.Plugin)
 		s.WriteString(`":`)
-		if fn := runtime.FuncForPC(reflect.ValueOf(symbol.S).Pointer()); fn != nil {
-			s.WriteString(fn.Name())
-		} else {
-			s.WriteString(fmt.Sprintf("%#v", symbol.S))
-		}
+		s.WriteString(renderSymbol(symbol.S, runtime.FuncForPC))
 	}
 	s.WriteRune(']')
 	return s.String()
 }
 
+// renderSymbol returns the name of the specified func symbol, or a stable
+// “<func@0xADDR>” placeholder if the func's PC cannot be resolved, such as in
+// stripped binaries. Non-func symbols are rendered in Go syntax.
+func renderSymbol(sym any, funcForPC func(uintptr) *runtime.Func) string {
+	v := reflect.ValueOf(sym)
+	if v.Kind() != reflect.Func {
+		return fmt.Sprintf("%#v", sym)
+	}
+	if fn := funcForPC(v.Pointer()); fn != nil && fn.Name() != "" {
+		return fn.Name()
+	}
+	return fmt.Sprintf("<func@%#x>", v.Pointer())
+}
+
 // RegisterOption allows optional registration information to be passed to the
 // Register method of plugin groups.
 type RegisterOption func(symbolSetter)
 
 // Register a plugin-exposed symbol, with optional additional registration
-// information.
-func (g *PluginGroup[T]) Register(symbol T, opts ...RegisterOption) {
+// information. Register returns a [Registration] handle for later managing the
+// registered symbol, such as unregistering it. Register panics if a plugin of
+// the same name has already registered with this group, reporting the source
+// locations of both registrations.
+func (g *PluginGroup[T]) Register(symbol T, opts ...RegisterOption) *Registration[T] {
+	return g.registerSymbol(symbol, opts, 1)
+}
+
+// registerSymbol validates and completes the symbol and then registers it,
+// with offset specifying the stack frames to skip to the original caller.
+func (g *PluginGroup[T]) registerSymbol(symbol T, opts []RegisterOption, offset int) *Registration[T] {
+	s := Symbol[T]{S: symbol}
+	g.validate(s) // panics if mistreated to a non-function and non-interface type symbol.
+	s.complete(offset+1, runtime.Caller)
+	s, _ = g.register(s, opts)
+	return &Registration[T]{g: g, plugin: s.Plugin, seq: s.Seq}
+}
+
+// RegisterFactory registers a lazily constructed plugin-exposed symbol for the
+// explicitly named plugin, with optional additional registration information.
+// The factory gets called only when the symbol is requested for the first
+// time, such as by [PluginGroup.PluginSymbol], [PluginGroup.First], or
+// [PluginGroup.Winner], with the result being cached. Queries returning all
+// symbols, such as [PluginGroup.Symbols], construct all lazy symbols. As the
+// factory gets called while this plugin group is locked, the factory must not
+// access this plugin group.
+func (g *PluginGroup[T]) RegisterFactory(name string, factory func() T, opts ...RegisterOption) {
+	if symbolType := reflect.TypeFor[T](); symbolType.Kind() != reflect.Func && symbolType.Kind() != reflect.Interface {
+		panic(fmt.Errorf("%w: must be func or interface, but got %s", ErrInvalidSymbolType, symbolType))
+	}
+	if factory == nil {
+		panic(fmt.Errorf("%w: missing symbol factory", ErrNilSymbol))
+	}
+	s := Symbol[T]{Plugin: name, lazy: &lazySymbol[T]{factory: factory}}
+	s.complete(1, runtime.Caller)
+	g.register(s, opts)
+}
+
+// RegisterWrapping replaces the symbol of the named plugin with the symbol
+// returned by wrap when passed the previous symbol, such as for layering
+// decorators under a single plugin name. The wrapping symbol keeps the
+// registration information of the previous symbol, unless overridden by the
+// optional additional registration information. RegisterWrapping returns an
+// error if there is no such named plugin, the wrapping symbol is invalid, or
+// the plugin has been changed concurrently while wrapping.
+func (g *PluginGroup[T]) RegisterWrapping(name string, wrap func(prev T) T, opts ...RegisterOption) (err error) {
+	g.lookupLock()
+	prev, ok := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	g.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("cannot wrap unknown plugin %q", name)
+	}
+	s := prev
+	s.S, s.lazy, s.inherited = wrap(prev.symbol()), nil, false
+	for _, option := range opts {
+		option(&s)
+	}
+	s.Plugin = name
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("cannot wrap plugin %q: %w", name, panicError(p))
+		}
+	}()
+	g.validate(s) // panics if the wrapping symbol is invalid.
+	s.complete(1, runtime.Caller)
+	s.Seq = registrations.Add(1)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.frozen {
+		return fmt.Errorf("cannot wrap plugin %q: %w", name, g.frozenError())
+	}
+	for _, symbols := range [][]Symbol[T]{g.symbols, g.hidden} {
+		if idx := slices.IndexFunc(symbols, func(s Symbol[T]) bool { return s.Seq == prev.Seq }); idx >= 0 {
+			symbols[idx] = s
+			g.ordered = false
+			g.registeredCount++
+			g.signal()
+			g.notify(PluginRegistered, s)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot wrap plugin %q as it changed concurrently", name)
+}
+
+// registrations is the monotonic sequence counter of symbol registrations
+// across all plugin groups.
+var registrations atomic.Uint64
+
+// RegisterDefault registers a plugin-exposed default symbol, with optional
+// additional registration information. Default symbols are only exposed as
+// long as there are no regular symbols registered in this group; as soon as a
+// regular symbol gets registered, all default symbols are hidden.
+func (g *PluginGroup[T]) RegisterDefault(symbol T, opts ...RegisterOption) {
+	s := Symbol[T]{S: symbol, fallback: true}
+	g.validate(s) // panics if mistreated to a non-function and non-interface type symbol.
+	s.complete(1, runtime.Caller)
+	g.register(s, opts)
+}
+
+// IsUsingDefault returns true if this plugin group exposes only default
+// symbols registered using [PluginGroup.RegisterDefault], because no regular
+// symbols have been registered (yet), such as for logging that the built-in
+// default is in use. Otherwise, including when there aren't any symbols at
+// all, IsUsingDefault returns false.
+func (g *PluginGroup[T]) IsUsingDefault() bool {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	all := g.all()
+	return !slices.ContainsFunc(all, func(s Symbol[T]) bool { return !s.fallback && g.exposed(s) }) &&
+		slices.ContainsFunc(all, func(s Symbol[T]) bool { return s.fallback && g.exposed(s) })
+}
+
+// RegisterAssert registers a plugin-exposed symbol with the specified plugin
+// group, with optional additional registration information, after asserting
+// that the symbol additionally implements the (interface) type U. If the symbol
+// doesn't implement U, RegisterAssert panics. This pushes capability checks to
+// registration time instead of failing type assertions much later.
+func RegisterAssert[T, U any](g *PluginGroup[T], symbol T, opts ...RegisterOption) {
+	if _, ok := any(symbol).(U); !ok {
+		panic(fmt.Errorf("%w %s, but got %T",
+			ErrNotImplemented, reflect.TypeFor[U](), symbol))
+	}
 	s := Symbol[T]{S: symbol}
-	s.Validate() // panics if mistreated to a non-function and non-interface type symbol.
+	g.validate(s) // panics if mistreated to a non-function and non-interface type symbol.
+	s.complete(1, runtime.Caller)
+	g.register(s, opts)
+}
+
+// RegisterAs registers a plugin-exposed symbol implementing the interface type
+// I with the specified (untyped) plugin group, with optional additional
+// registration information. In contrast to [PluginGroup.Register], the
+// interface type I gets recorded in the registered [Symbol], so that
+// introspection can report the declared interface type instead of the
+// concrete type of the symbol. RegisterAs panics if I isn't an interface type.
+func RegisterAs[I any](g *PluginGroup[any], impl I, opts ...RegisterOption) {
+	ifaceType := reflect.TypeFor[I]()
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Errorf("declared symbol %w, but got %s", ErrNotInterface, ifaceType))
+	}
+	s := Symbol[any]{S: impl, Interface: ifaceType}
+	g.validate(s) // panics if mistreated to a nil symbol.
 	s.complete(1, runtime.Caller)
+	g.register(s, opts)
+}
+
+// RegisterReflect registers a plugin-exposed symbol only known at runtime with
+// the plugin group for the specified symbol type, with optional additional
+// registration information. The symbol must be assignable to the group's
+// symbol type. This bridges untyped symbols, such as those returned by
+// [plugin.Plugin.Lookup], to the typed plugin groups. The plugin group for the
+// specified symbol type must already exist, as otherwise RegisterReflect
+// returns an error.
+func RegisterReflect(groupType reflect.Type, symbol any, opts ...RegisterOption) error {
+	groupsmu.Lock()
+	group, ok := lookupGroup(groupType).(untypedGroup)
+	groupsmu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w for symbol type %s", ErrNoGroup, groupType)
+	}
+	return group.registerAny(symbol, opts, 1)
+}
+
+// registerAny registers the untyped symbol after checking that it is
+// assignable to this group's symbol type, with offset specifying the stack
+// frames to skip to the original caller.
+func (g *PluginGroup[T]) registerAny(symbol any, opts []RegisterOption, offset int) (err error) {
+	symbolType := reflect.TypeFor[T]()
+	v := reflect.ValueOf(symbol)
+	if !v.IsValid() || !v.Type().AssignableTo(symbolType) {
+		return fmt.Errorf("%w: %T is not assignable to %s", ErrInvalidSymbolType, symbol, symbolType)
+	}
+	s := Symbol[T]{S: v.Convert(symbolType).Interface().(T)}
+	defer func() {
+		if p := recover(); p != nil {
+			err = panicError(p)
+		}
+	}()
+	g.validate(s) // panics if mistreated to a non-function and non-interface type symbol.
+	s.complete(offset+1, runtime.Caller)
+	g.register(s, opts)
+	return nil
+}
+
+// validate the symbol, panicking if it is invalid, and taking into account
+// whether this group accepts typed nil interface symbols. Valid symbols are
+// then additionally checked by the group's validator, if any.
+func (g *PluginGroup[T]) validate(s Symbol[T]) {
+	g.mu.RLock()
+	allowNil, validator := g.allowNil, g.validator
+	g.mu.RUnlock()
+	s.validate(allowNil)
+	if validator == nil {
+		return
+	}
+	if err := validator(s.S); err != nil {
+		panic(fmt.Errorf("%w: %w", ErrRejected, err))
+	}
+}
+
+// register the completed symbol, applying the registration options. register
+// returns the registered symbol and true, or the symbol and false if it was
+// skipped.
+func (g *PluginGroup[T]) register(s Symbol[T], opts []RegisterOption) (Symbol[T], bool) {
 	for _, option := range opts {
 		option(&s)
 	}
+	if !s.supported() {
+		return s, false
+	}
+	s.warnAutoName()
+	s.Seq = registrations.Add(1)
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if g.frozen {
+		panic(g.frozenError())
+	}
+	if s.derived && g.explicitNames {
+		panic(g.explicitNameError())
+	}
+	if g.duplicate(s) {
+		s.Seq = 0
+		return s, false
+	}
+	if err := collision(s, g.all()); err != nil {
+		panic(err)
+	}
 	g.ordered = false
 	g.symbols = append(g.symbols, s)
+	g.registeredCount++
+	g.signal()
+	g.notify(PluginRegistered, s)
+	return s, true
 }
 
 // WithPlugin registers an exposed symbol with the given plugin name in
@@ -130,176 +562,1344 @@ func WithPlacement(placement string) func(symbolSetter) {
 	}
 }
 
+// WithFinalizer registers an exposed symbol with the given finalizer in
+// [plugger.PluginGroup.Register]. The finalizer gets called when the symbol is
+// removed from its plugin group, such as by [plugger.PluginGroup.RemoveMatching]
+// and [plugger.PluginGroup.Clear]. Finalizers are run outside the plugin
+// group's lock, so they can safely access the plugin group.
+func WithFinalizer(fn func()) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setFinalizer(fn)
+	}
+}
+
+// WithID registers an exposed symbol with the given stable numeric ID in
+// [plugger.PluginGroup.Register], such as for mapping wire protocol tags to
+// plugin symbols. The ID 0 is reserved to mean “no ID”. IDs must be unique
+// within a plugin group.
+func WithID(id uint32) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setID(id)
+	}
+}
+
+// WithPlatforms registers an exposed symbol only on the listed platforms in
+// [plugger.PluginGroup.Register], otherwise the symbol gets silently skipped.
+// Platforms can be given as GOOS values, such as "linux", GOARCH values, such
+// as "arm64", or GOOS/GOARCH pairs, such as "linux/arm64". In contrast to build
+// tags, this allows for a single binary that selects the applicable plugins at
+// runtime.
+func WithPlatforms(platforms ...string) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setPlatforms(append([]string{}, platforms...))
+	}
+}
+
+// WithInit registers an exposed symbol with the given initialization function
+// in [plugger.PluginGroup.Register]. The initialization functions of a plugin
+// group are then called in the group's plugin order by
+// [plugger.PluginGroup.InitAll].
+func WithInit(fn func() error) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setInit(fn)
+	}
+}
+
+// WithConflicts registers an exposed symbol in
+// [plugger.PluginGroup.Register] as being mutually exclusive with the named
+// plugins. Use [plugger.PluginGroup.CheckConflicts] to detect conflicting
+// plugins being registered at the same time.
+func WithConflicts(names ...string) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setConflicts(append([]string{}, names...))
+	}
+}
+
+// WithAliases registers an exposed symbol with the given alternative names, in
+// preference order, in [plugger.PluginGroup.Register]. Aliases are taken into
+// account by [plugger.PluginGroup.PluginSymbolAny], such as for content
+// negotiation where a plugin serves multiple protocol names.
+func WithAliases(names ...string) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setAliases(append([]string{}, names...))
+	}
+}
+
+// WithExperimental registers an exposed symbol as experimental in
+// [plugger.PluginGroup.Register]. Experimental symbols are registered, but not
+// exposed unless enabled using [plugger.PluginGroup.EnableExperimental], or by
+// setting the environment variable PLUGGER_EXPERIMENTAL=1.
+func WithExperimental() func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setExperimental()
+	}
+}
+
+// WithVersion registers an exposed symbol with the given semantic version in
+// [plugger.PluginGroup.Register], such as "1.2.3" or "v2.0.0-rc.1". Use
+// [plugger.PluginGroup.LatestByBaseName] to select the newest version among
+// multiple versions of the same plugin.
+func WithVersion(semver string) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setVersion(semver)
+	}
+}
+
+// WithSortKey registers an exposed symbol with the given sort key in
+// [plugger.PluginGroup.Register]. When ordering the symbols by name, the sort
+// key is used instead of the plugin name, such as for user-facing plugin names
+// combined with a machine-friendly ordering scheme. Placement hints still
+// reference plugins by their names.
+func WithSortKey(key string) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setSortKey(key)
+	}
+}
+
+// WithConfig registers an exposed symbol with the given plugin configuration in
+// [plugger.PluginGroup.Register], keeping a plugin's symbol and configuration
+// together. Use [plugger.PluginConfig] to retrieve the configuration.
+func WithConfig(config any) func(symbolSetter) {
+	return func(s symbolSetter) {
+		s.setConfig(config)
+	}
+}
+
 // Symbols returns all symbols (functions or interfaces) exposed by the plugins
 // in this Group. This is always a clean and ordered copy of the list of exposed
 // symbols.
 func (g *PluginGroup[T]) Symbols() []T {
 	g.lock()
 	defer g.unlock()
+	g.checkEmpty()
 
 	s := make([]T, 0, len(g.symbols))
 	for _, symbol := range g.symbols {
-		s = append(s, symbol.S)
+		s = append(s, symbol.symbol())
 	}
 	return s
 }
 
-// PluginsSymbols returns all exposed symbols together with the names of the
-// plugins exposing them. This is always a clean and ordered copy of the
-// [Symbol] objects.
-func (g *PluginGroup[T]) PluginsSymbols() []Symbol[T] {
+// SymbolsUnsafe returns the symbols exposed by the plugins in this Group in
+// order, like [PluginGroup.Symbols], but without copying them for each call,
+// for read paths where the copy has been profiled as a bottleneck. The returned
+// list is shared and thus strictly read-only: callers must never modify it.
+// The returned list doesn't reflect any later changes to this Group, such as
+// registrations, so callers must not retain it across such changes.
+func (g *PluginGroup[T]) SymbolsUnsafe() []T {
 	g.lock()
-	defer g.unlock()
-
-	return slices.Clone(g.symbols)
+	if g.view != nil && g.viewVersion == g.version {
+		view := g.view
+		g.unlock()
+		return view
+	}
+	g.unlock()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := g.ensureOrdered(); err != nil {
+		panic(err)
+	}
+	if g.view == nil || g.viewVersion != g.version {
+		// Never modify a cached list in place, as callers might still use it.
+		view := make([]T, 0, len(g.symbols))
+		for _, symbol := range g.symbols {
+			view = append(view, symbol.symbol())
+		}
+		g.view, g.viewVersion = view, g.version
+	}
+	return g.view
 }
 
-// PluginSymbol returns the exposed symbol of the plugin identified by its name,
-// or the zero symbol value if no such named plugin exists in this symbol group.
-func (g *PluginGroup[T]) PluginSymbol(name string) T {
+// SymbolsExcept returns the symbols exposed by the plugins in this Group, except
+// for the symbols of the named plugins. This is always a clean and ordered copy
+// of the list of exposed symbols.
+func (g *PluginGroup[T]) SymbolsExcept(names ...string) []T {
 	g.lock()
 	defer g.unlock()
 
+	s := make([]T, 0, len(g.symbols))
 	for _, symbol := range g.symbols {
-		if symbol.Plugin == name {
-			return symbol.S
+		if slices.Contains(names, symbol.Plugin) {
+			continue
 		}
+		s = append(s, symbol.symbol())
 	}
-	var zero T
-	return zero
+	return s
 }
 
-// Plugins returns the names of all plugins exposing symbols in this plugin
-// group. The returned list is always ordered, based on the plugin names and
-// placement hints.
-func (g *PluginGroup[T]) Plugins() []string {
+// SymbolsShuffled returns all symbols exposed by the plugins in this Group in
+// a randomly shuffled order, using the specified random source. The canonical
+// order of the symbols in this Group is left untouched. This is always a fresh
+// copy of the list of exposed symbols.
+func (g *PluginGroup[T]) SymbolsShuffled(r *rand.Rand) []T {
+	s := g.Symbols()
+	r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	return s
+}
+
+// First returns the first symbol in the ordered list of symbols exposed by the
+// plugins in this Group and true, or the zero symbol value and false if this
+// Group is empty.
+func (g *PluginGroup[T]) First() (T, bool) {
 	g.lock()
 	defer g.unlock()
 
-	plugins := make([]string, 0, len(g.symbols))
-	for _, symbol := range g.symbols {
-		plugins = append(plugins, symbol.Plugin)
+	if len(g.symbols) == 0 {
+		var zero T
+		return zero, false
 	}
-	return plugins
+	g.count(g.symbols[0].Plugin)
+	return g.symbols[0].symbol(), true
 }
 
-// Clears this plugin group's configuration (such as in unit tests).
-func (g *PluginGroup[T]) Clear() {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-	g.ordered = false
-	g.symbols = nil
+// Last returns the last symbol in the ordered list of symbols exposed by the
+// plugins in this Group and true, or the zero symbol value and false if this
+// Group is empty.
+func (g *PluginGroup[T]) Last() (T, bool) {
+	g.lock()
+	defer g.unlock()
+
+	if len(g.symbols) == 0 {
+		var zero T
+		return zero, false
+	}
+	g.count(g.symbols[len(g.symbols)-1].Plugin)
+	return g.symbols[len(g.symbols)-1].symbol(), true
 }
 
-// Save returns a copy of this plugin group's current plugin configuration, for
-// later restoration using the Restore method.
-func (g *PluginGroup[T]) Backup() GroupStash[T] {
-	g.mu.RLock()
-	defer g.mu.RUnlock()
-	return GroupStash[T]{
-		ordered: g.ordered,
-		symbols: slices.Clone(g.symbols),
+// IndexOf returns the zero-based index of the named plugin in the ordered list
+// of plugins in this Group, or -1 if there is no such plugin.
+func (g *PluginGroup[T]) IndexOf(name string) int {
+	g.lock()
+	defer g.unlock()
+
+	return slices.IndexFunc(g.symbols, func(s Symbol[T]) bool { return s.Plugin == name })
+}
+
+// TopN returns at most the first n symbols in the ordered list of symbols
+// exposed by the plugins in this Group, such as for trying only the top n
+// backends. If this Group has fewer than n symbols, TopN returns all of them.
+func (g *PluginGroup[T]) TopN(n int) []T {
+	g.lock()
+	defer g.unlock()
+
+	n = max(0, min(n, len(g.symbols)))
+	s := make([]T, 0, n)
+	for _, symbol := range g.symbols[:n] {
+		s = append(s, symbol.symbol())
 	}
+	return s
 }
 
-// Restore a plugin group's former plugin configuration from a backup previously
-// created by the Backup method.
-func (g *PluginGroup[T]) Restore(s GroupStash[T]) {
+// WinnerPolicy specifies which symbol [PluginGroup.Winner] selects.
+type WinnerPolicy int
+
+// The supported winner selection policies.
+const (
+	LastWins  WinnerPolicy = iota // the last symbol in order wins (default).
+	FirstWins                     // the first symbol in order wins.
+)
+
+// SetWinnerPolicy sets which symbol [PluginGroup.Winner] selects; the default
+// is [LastWins].
+func (g *PluginGroup[T]) SetWinnerPolicy(policy WinnerPolicy) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.ordered = s.ordered
-	g.symbols = slices.Clone(s.symbols)
+	g.winner = policy
 }
 
-// sort the plugins by name and optionally by reference; that is, individual
-// plugins can claim to get to the front/end, or before/after a another named
-// plugin. This method must be called under write lock.
-//
-// The plugin ordering mechanism is with a nod to Jeremy Ruston and his
-// incredible TiddlyWiki (in particular, its list and module sorting).
-func (g *PluginGroup[T]) sort() {
-	// First, sort lexicographically by plugin name (not: by plugin path).
-	sort.Slice(g.symbols, func(a, b int) bool {
-		return g.symbols[a].Plugin < g.symbols[b].Plugin
-	})
-	// Second, honor the optional positional requests of individual plugins.
-	// Or, at least try to do so...
-	symbols := slices.Clone(g.symbols)
-	for _, symbol := range g.symbols {
-		// Find the next plugin to process from the original list on in the
-		// current and potentially modified list, because we need to work on the
-		// current list when shuffling plugins around.
-		var idx int
-		var sym Symbol[T]
-		for idx, sym = range symbols {
-			if sym.Plugin == symbol.Plugin {
-				break
-			}
+// Winner returns the single symbol winning in this Group, together with the
+// name of its plugin and true, or the zero symbol value, "" and false if this
+// Group is empty. By default, the last symbol in order wins, formalizing the
+// “override chain” pattern where overriding plugins get placed after the
+// plugins they override. Use [PluginGroup.SetWinnerPolicy] to let the first
+// symbol win instead.
+func (g *PluginGroup[T]) Winner() (T, string, bool) {
+	g.lock()
+	defer g.unlock()
+
+	if len(g.symbols) == 0 {
+		var zero T
+		return zero, "", false
+	}
+	winner := g.symbols[len(g.symbols)-1]
+	if g.winner == FirstWins {
+		winner = g.symbols[0]
+	}
+	g.count(winner.Plugin)
+	return winner.symbol(), winner.Plugin, true
+}
+
+// Best returns the symbol with the highest score in this Group, together with
+// the name of its plugin and true, or the zero symbol value, "" and false if
+// this Group is empty. Ties are broken in favor of the symbol coming first in
+// this Group's order. Best works on a snapshot of this Group's symbols, so
+// score can safely access this Group.
+func (g *PluginGroup[T]) Best(score func(name string, sym T) int) (T, string, bool) {
+	var best Symbol[T]
+	var bestScore int
+	found := false
+	for _, symbol := range g.PluginsSymbols() {
+		if s := score(symbol.Plugin, symbol.S); !found || s > bestScore {
+			best, bestScore, found = symbol, s, true
 		}
-		pos := idx // start with no change in a plugin's sequence position
-		// Does the plugin want to be positioned either before a specifically
-		// named other plugin or at the beginning?
-		if strings.HasPrefix(symbol.Placement, "<") {
-			before := symbol.Placement[1:]
-			if before == "" {
-				pos = 0 // tangarines FIRST (*all* of them, *snicker*)
-			} else {
-				// Find the named plugin at its current position; not at the
-				// original position, that wouldn't make sense and mix up the
-				// original intention.
-				for i, p := range symbols {
-					if before == p.Plugin {
-						pos = i
-						break
-					}
-				}
-			}
+	}
+	return best.S, best.Plugin, found
+}
+
+// RangeSymbols calls fn sequentially for each symbol in this Group, passing
+// the symbol's index in the ordered list of symbols, its plugin name, and the
+// symbol itself. If fn returns false, RangeSymbols stops the iteration.
+// RangeSymbols works on a snapshot of this Group's symbols, so fn can safely
+// access this Group.
+func (g *PluginGroup[T]) RangeSymbols(fn func(i int, name string, sym T) bool) {
+	for i, symbol := range g.PluginsSymbols() {
+		if !fn(i, symbol.Plugin, symbol.S) {
+			return
 		}
-		// Does the plugin want to be positioned either after another
-		// specifically named plugin or at the end of the sequence?
-		if strings.HasPrefix(symbol.Placement, ">") {
-			after := symbol.Placement[1:]
-			if after == "" {
-				pos = len(symbols)
-			} else {
-				// Find the named plugin at its current position; not at the
-				// original position, that wouldn't make sense and mix up the
-				// original intention.
-				for i, p := range symbols {
-					if after == p.Plugin {
-						pos = i + 1
-						break
-					}
-				}
-			}
+	}
+}
+
+// InitAll calls the initialization functions of the plugins in this Group in
+// order, as registered using [WithInit]. InitAll stops at the first
+// initialization function failing and returns its error, annotated with the
+// name of the failing plugin. InitAll works on a snapshot of this Group's
+// symbols, so the initialization functions can safely access this Group.
+func (g *PluginGroup[T]) InitAll() error {
+	for _, symbol := range g.PluginsSymbols() {
+		if symbol.init == nil {
+			continue
+		}
+		if err := symbol.init(); err != nil {
+			return fmt.Errorf("cannot initialize plugin %q: %w", symbol.Plugin, err)
 		}
-		symbols = move(symbols, idx, pos)
 	}
-	g.symbols = symbols
+	return nil
 }
 
-// lock locks the plugin group against concurrent write changes and sorts the
-// plugin exposed list of symbols, if necessary. The caller needs to (defer to)
-// unlock after having done its work.
-func (g *PluginGroup[T]) lock() {
-	g.mu.RLock()
-	// As we cannot downgrade a write lock into a read lock atomatically, we
-	// need to rinse and repeat until got our read lock on a sorted exposed
-	// plugin symbols list...
-	for !g.ordered { // https://github.com/golang/go/issues/4026#issuecomment-66069822
-		g.mu.RUnlock()
-		// Here, another goroutine might win an unintended race with us to sort
-		// the list of exposed plugin symbols, so skip the sort operation if we
-		// finally got the write lock on a sorted list.
-		g.mu.Lock()
-		if !g.ordered {
-			g.sort()
-			g.ordered = true
+// Stream returns a channel that receives the ordered symbols of this Group one
+// after another, and that gets closed after the last symbol or when ctx gets
+// cancelled, whichever comes first. Stream works on a snapshot of this Group's
+// symbols taken before streaming, so later registrations don't affect the
+// stream.
+func (g *PluginGroup[T]) Stream(ctx context.Context) <-chan T {
+	symbols := g.Symbols()
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, symbol := range symbols {
+			select {
+			case ch <- symbol:
+			case <-ctx.Done():
+				return
+			}
 		}
-		g.mu.Unlock()
-		// Here, the list might get unsorted again if we're unlucky.
-		g.mu.RLock()
+	}()
+	return ch
+}
+
+// PluginsSymbols returns all exposed symbols together with the names of the
+// plugins exposing them. This is always a clean and ordered copy of the
+// [Symbol] objects.
+func (g *PluginGroup[T]) PluginsSymbols() []Symbol[T] {
+	g.lock()
+	defer g.unlock()
+
+	return materialize(slices.Clone(g.symbols))
+}
+
+// Unordered returns all exposed symbols together with the names of the plugins
+// exposing them in registration order, and not in the sorted order. In contrast
+// to [PluginGroup.PluginsSymbols], Unordered doesn't trigger sorting this
+// Group, for callers applying their own custom ordering. This is always a
+// clean copy of the [Symbol] objects.
+func (g *PluginGroup[T]) Unordered() []Symbol[T] {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	all := g.all()
+	regular := slices.ContainsFunc(all, func(s Symbol[T]) bool { return !s.fallback && g.exposed(s) })
+	symbols := slices.DeleteFunc(all, func(s Symbol[T]) bool {
+		return !g.exposed(s) || (regular && s.fallback)
+	})
+	sort.SliceStable(symbols, func(a, b int) bool { return symbols[a].Seq < symbols[b].Seq })
+	return materialize(symbols)
+}
+
+// materialize the lazily constructed symbols in the given list of symbols,
+// returning the list.
+func materialize[T any](symbols []Symbol[T]) []Symbol[T] {
+	for idx := range symbols {
+		symbols[idx].S = symbols[idx].symbol()
+	}
+	return symbols
+}
+
+// PluginSymbol returns the exposed symbol of the plugin identified by its name,
+// or the zero symbol value if no such named plugin exists in this symbol group.
+func (g *PluginGroup[T]) PluginSymbol(name string) T {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	if symbol, ok := g.find(func(s Symbol[T]) bool { return s.Plugin == name }); ok {
+		g.count(symbol.Plugin)
+		return symbol.symbol()
+	}
+	var zero T
+	return zero
+}
+
+// PluginSymbolAny returns the exposed symbol of the first plugin matching any
+// of the specified candidate names in the given order, together with the
+// matching candidate name and true. Otherwise, it returns the zero symbol
+// value, "" and false. For each candidate name, a plugin with that name takes
+// precedence over plugins registered with the name as an alias using
+// [WithAliases]; multiple plugins with the same alias match in plugin order.
+func (g *PluginGroup[T]) PluginSymbolAny(names ...string) (T, string, bool) {
+	g.lock()
+	defer g.unlock()
+
+	for _, name := range names {
+		idx := slices.IndexFunc(g.symbols, func(s Symbol[T]) bool { return s.Plugin == name })
+		if idx < 0 {
+			idx = slices.IndexFunc(g.symbols, func(s Symbol[T]) bool {
+				return slices.Contains(s.aliases, name)
+			})
+		}
+		if idx >= 0 {
+			g.count(g.symbols[idx].Plugin)
+			return g.symbols[idx].symbol(), name, true
+		}
+	}
+	var zero T
+	return zero, "", false
+}
+
+// PluginByID returns the exposed symbol with the specified stable numeric ID
+// and true, or the zero symbol value and false if there is no such symbol in
+// this symbol group.
+func (g *PluginGroup[T]) PluginByID(id uint32) (T, bool) {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	if id != 0 {
+		if symbol, ok := g.find(func(s Symbol[T]) bool { return s.ID == id }); ok {
+			g.count(symbol.Plugin)
+			return symbol.symbol(), true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// PluginPackage returns the import path of the package that registered the
+// symbol of the plugin identified by its name, or "" if either no such named
+// plugin exists in this symbol group or its package is unknown.
+func (g *PluginGroup[T]) PluginPackage(name string) string {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	symbol, _ := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	return symbol.Package
+}
+
+// PluginSource returns the source file and line where the symbol of the plugin
+// identified by its name was registered, or "" and 0 if either no such named
+// plugin exists in this symbol group or its source location is unknown.
+func (g *PluginGroup[T]) PluginSource(name string) (file string, line int) {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	symbol, _ := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	return symbol.File, symbol.Line
+}
+
+// PluginConfig returns the configuration of type C registered using
+// [WithConfig] for the plugin identified by its name in the specified plugin
+// group, and true. If there is no such named plugin, the plugin has no
+// configuration, or its configuration isn't of type C, PluginConfig returns the
+// zero value of C and false.
+func PluginConfig[C, T any](g *PluginGroup[T], name string) (C, bool) {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	symbol, _ := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	config, ok := symbol.config.(C)
+	return config, ok
+}
+
+// find returns the first exposed symbol matching the specified predicate and
+// true, or the zero Symbol and false. As the order of the symbols doesn't
+// matter for such lookups, find works on the potentially unsorted and not yet
+// partitioned symbols, avoiding sorting them first. Thus, find only considers
+// default symbols in the absence of any regular symbols, and experimental
+// symbols only when enabled. This method must be called under (read) lock.
+func (g *PluginGroup[T]) find(match func(Symbol[T]) bool) (Symbol[T], bool) {
+	all := g.all()
+	regular := func(s Symbol[T]) bool { return !s.fallback && g.exposed(s) }
+	if idx := slices.IndexFunc(all, func(s Symbol[T]) bool { return regular(s) && match(s) }); idx >= 0 {
+		return all[idx], true
+	}
+	if slices.ContainsFunc(all, regular) {
+		return Symbol[T]{}, false
+	}
+	if idx := slices.IndexFunc(all, func(s Symbol[T]) bool { return g.exposed(s) && match(s) }); idx >= 0 {
+		return all[idx], true
+	}
+	return Symbol[T]{}, false
+}
+
+// Plugins returns the names of all plugins exposing symbols in this plugin
+// group. The returned list is always ordered, based on the plugin names and
+// placement hints.
+func (g *PluginGroup[T]) Plugins() []string {
+	g.lock()
+	defer g.unlock()
+	g.checkEmpty()
+
+	plugins := make([]string, 0, len(g.symbols))
+	for _, symbol := range g.symbols {
+		plugins = append(plugins, symbol.Plugin)
+	}
+	return plugins
+}
+
+// OrderHash returns a hash over the ordered names of the plugins in this plugin
+// group. The hash is stable across process runs for the same plugins in the
+// same order, so it can be used to detect changes in the plugin topology, such
+// as for invalidating caches.
+func (g *PluginGroup[T]) OrderHash() uint64 {
+	g.lock()
+	defer g.unlock()
+
+	h := fnv.New64a()
+	for _, symbol := range g.symbols {
+		_, _ = h.Write([]byte(symbol.Plugin))
+		_, _ = h.Write([]byte{0}) // separate names unambiguously.
+	}
+	return h.Sum64()
+}
+
+// PluginsUnder returns the names of the plugins in this plugin group that are
+// located under the specified path-style prefix, such as "vendor/category".
+// The returned list is always ordered, based on the plugin names and placement
+// hints.
+func (g *PluginGroup[T]) PluginsUnder(prefix string) []string {
+	g.lock()
+	defer g.unlock()
+
+	var plugins []string
+	for _, symbol := range g.symbols {
+		if under(symbol.Plugin, prefix) {
+			plugins = append(plugins, symbol.Plugin)
+		}
+	}
+	return plugins
+}
+
+// SelectUnder returns the symbols exposed by the plugins in this plugin group
+// that are located under the specified path-style prefix, such as
+// "vendor/category". The returned list is always ordered.
+func (g *PluginGroup[T]) SelectUnder(prefix string) []T {
+	g.lock()
+	defer g.unlock()
+
+	var s []T
+	for _, symbol := range g.symbols {
+		if under(symbol.Plugin, prefix) {
+			s = append(s, symbol.symbol())
+		}
+	}
+	return s
+}
+
+// SelectFunc returns the symbols of this plugin group whose symbol values
+// satisfy the specified predicate, such as interface symbols additionally
+// implementing another interface. In contrast to [PluginGroup.SelectUnder],
+// SelectFunc selects by symbol value instead of by plugin name. The returned
+// list is always ordered. SelectFunc works on a snapshot of this Group's
+// symbols, so pred can safely access this Group.
+func (g *PluginGroup[T]) SelectFunc(pred func(sym T) bool) []Symbol[T] {
+	var s []Symbol[T]
+	for _, symbol := range g.PluginsSymbols() {
+		if pred(symbol.S) {
+			s = append(s, symbol)
+		}
+	}
+	return s
+}
+
+// SymbolsGrouped returns the symbols exposed by the plugins in this plugin
+// group, grouped by the keys derived from the plugin names using the specified
+// key function, such as a vendor prefix. Within each group, the symbols keep
+// their order. This is always a fresh map of fresh symbol lists. SymbolsGrouped
+// works on a snapshot of this Group's symbols, so key can safely access this
+// Group.
+func (g *PluginGroup[T]) SymbolsGrouped(key func(name string) string) map[string][]T {
+	grouped := map[string][]T{}
+	for _, symbol := range g.PluginsSymbols() {
+		k := key(symbol.Plugin)
+		grouped[k] = append(grouped[k], symbol.S)
+	}
+	return grouped
+}
+
+// under returns true if the specified hierarchical plugin name is located
+// under the specified path-style prefix, that is, either equals the prefix or
+// begins with the prefix followed by a "/" separator.
+func under(name, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return name == prefix || strings.HasPrefix(name, prefix+"/")
+}
+
+// SetDefaultPlacement sets the placement hint to apply to all symbols in this
+// group that have been registered without an explicit placement hint. Explicit
+// placement hints always take precedence. Passing "" removes the default
+// placement.
+func (g *PluginGroup[T]) SetDefaultPlacement(placement string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.defaultPlacement = placement
+}
+
+// SetOrdering sets the basic ordering of the symbols in this group, before
+// applying placement hints. The default is [OrderByName], ordering the symbols
+// lexicographically by their plugin names. Alternatively, [OrderByRegistration]
+// orders the symbols in the sequence they were registered in, such as for
+// middleware chains that should follow the import order.
+func (g *PluginGroup[T]) SetOrdering(ordering Ordering) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.ordering = ordering
+}
+
+// SetCollation sets how plugin names get compared when ordering the symbols of
+// this plugin group by name; the default is [CollateBytes]. In contrast to the
+// default byte-wise comparison, [CollateCaseInsensitive] doesn't order all
+// uppercase names before lowercase names, and [CollateUnicode] additionally
+// orders accented and other non-ASCII names in dictionary order.
+func (g *PluginGroup[T]) SetCollation(collation Collation) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.collation = collation
+}
+
+// RequireExplicitName enables or disables requiring explicit plugin names when
+// registering symbols with this group, using [WithPlugin]. When required,
+// registering a symbol without an explicit plugin name panics instead of
+// deriving the plugin name from the caller's directory.
+func (g *PluginGroup[T]) RequireExplicitName(require bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.explicitNames = require
+}
+
+// DeduplicateSymbols enables or disables skipping the registration of symbols
+// whose values are identical to already registered symbols, regardless of
+// their plugin names, such as when generated code accidentally registers the
+// same func multiple times under different derived names. Func symbols are
+// identical only when they are the very same func value, so separately created
+// closures and method values are never considered identical, even if they share
+// the same code. Interface symbols are identical when they have identical
+// dynamic types and values, such as the same pointer.
+func (g *PluginGroup[T]) DeduplicateSymbols(dedupe bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.dedupe = dedupe
+}
+
+// duplicate returns true if deduplication is enabled and the specified symbol
+// value is identical to the value of an already registered symbol. This
+// method must be called under (read) lock.
+func (g *PluginGroup[T]) duplicate(s Symbol[T]) bool {
+	if !g.dedupe || s.lazy != nil {
+		return false
+	}
+	return slices.ContainsFunc(g.all(), func(registered Symbol[T]) bool {
+		return registered.lazy == nil && sameSymbol(registered.S, s.S)
+	})
+}
+
+// collision returns an error if the plugin name or stable numeric ID of the
+// specified symbol collides with any of the specified registered symbols,
+// otherwise nil. Inherited symbols don't collide with a symbol of the same
+// plugin name, as this symbol then overrides the inherited one.
+func collision[T any](s Symbol[T], registered []Symbol[T]) error {
+	for _, symbol := range registered {
+		if symbol.inherited && symbol.Plugin == s.Plugin {
+			continue
+		}
+		if symbol.Plugin == s.Plugin {
+			return fmt.Errorf("%w %q: first at %s, again at %s",
+				ErrDuplicatePlugin, s.Plugin, symbol.source(), s.source())
+		}
+	}
+	if s.ID == 0 {
+		return nil
+	}
+	for _, symbol := range registered {
+		if symbol.inherited && symbol.Plugin == s.Plugin {
+			continue
+		}
+		if symbol.ID == s.ID {
+			return fmt.Errorf("%w %d for plugins %q and %q",
+				ErrDuplicateSymbol, s.ID, symbol.Plugin, s.Plugin)
+		}
+	}
+	return nil
+}
+
+// WarnOnEmptyQuery enables or disables logging a warning, only once, when
+// querying the symbols or plugins of this plugin group before any symbol has
+// been registered. Such queries almost always indicate a forgotten import of a
+// plugin package, such as a missing underscore import.
+func (g *PluginGroup[T]) WarnOnEmptyQuery(warn bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.warnEmpty = warn
+}
+
+// checkEmpty logs a warning, but only once, if enabled and this plugin group
+// doesn't have any registered symbols. This method must be called under (read)
+// lock.
+func (g *PluginGroup[T]) checkEmpty() {
+	if !g.warnEmpty || len(g.symbols) > 0 || len(g.hidden) > 0 {
+		return
+	}
+	g.emptyWarned.Do(func() {
+		log.Printf("plugger: group %s queried before any plugin registered; missing plugin import?",
+			groupKeyName(g.SymbolType()))
+	})
+}
+
+// Freeze this plugin group, so that any further registration panics, or
+// returns an error in case of [Registrar.Commit], [PluginGroup.Merge], and
+// [RegisterReflect]. Freezing enforces registering plugins only during
+// initialization, catching accidental or malicious late registrations. See
+// also [FreezeAll].
+func (g *PluginGroup[T]) Freeze() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.frozen = true
+}
+
+// Seal freezes this plugin group and orders its symbols, additionally checking
+// that the required plugins are all present. Seal returns an error naming the
+// missing plugins, if any, such as when forgetting to import a plugin package.
+// Sealing a group thus combines registering plugins only during initialization
+// with a startup completeness check. See also [PluginGroup.Freeze].
+func (g *PluginGroup[T]) Seal(require ...string) error {
+	g.Freeze()
+	g.lock()
+	defer g.unlock()
+	var missing []string
+	for _, name := range require {
+		if !slices.ContainsFunc(g.symbols, func(s Symbol[T]) bool { return s.Plugin == name }) {
+			missing = append(missing, strconv.Quote(name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("group %s lacks required plugins %s",
+			reflect.TypeFor[T](), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// FreezeAll freezes all plugin groups existing at the time of the call. See
+// also [PluginGroup.Freeze].
+func FreezeAll() {
+	for _, group := range allGroups() {
+		group.Freeze()
+	}
+}
+
+// frozenError returns the error for registering with this frozen group.
+func (g *PluginGroup[T]) frozenError() error {
+	return fmt.Errorf("group %s is %w", reflect.TypeFor[T](), ErrFrozen)
+}
+
+// explicitNameError returns the error for registering a symbol without an
+// explicit plugin name with this group requiring explicit plugin names.
+func (g *PluginGroup[T]) explicitNameError() error {
+	return fmt.Errorf("%w for group %s", ErrExplicitNameRequired, reflect.TypeFor[T]())
+}
+
+// SetValidator sets a validator that checks every symbol registered with this
+// group after the built-in checks of [Symbol.Validate], rejecting symbols for
+// which the validator returns an error. Rejected symbols cause registration to
+// panic, or to return an error in case of [Registrar.Commit] and
+// [RegisterReflect]. Passing nil removes the validator. The validator doesn't
+// apply to symbols registered using [PluginGroup.RegisterFactory].
+func (g *PluginGroup[T]) SetValidator(validator func(T) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.validator = validator
+}
+
+// AllowNilSymbols enables or disables accepting typed nil interface symbols
+// when registering symbols with this group. By default, registering a typed nil
+// interface symbol panics. When allowed, the typed nil symbol gets registered
+// and then returned from queries, such as for intentional “null object”
+// plugins acting as explicit “do nothing” implementations. Untyped nil
+// symbols are always rejected.
+func (g *PluginGroup[T]) AllowNilSymbols(allow bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.allowNil = allow
+}
+
+// experimentalEnv enables experimental symbols in all plugin groups when the
+// environment variable PLUGGER_EXPERIMENTAL=1 is set.
+var experimentalEnv = os.Getenv("PLUGGER_EXPERIMENTAL") == "1"
+
+// EnableExperimental enables or disables exposing symbols registered as
+// experimental using [WithExperimental]. By default, experimental symbols are
+// registered but not exposed, unless the environment variable
+// PLUGGER_EXPERIMENTAL=1 is set.
+func (g *PluginGroup[T]) EnableExperimental(enable bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.experimental = enable
+	g.ordered = false
+	g.signal()
+}
+
+// exposed returns true if the specified symbol isn't experimental, or if
+// experimental symbols are enabled. This method must be called under (read)
+// lock.
+func (g *PluginGroup[T]) exposed(s Symbol[T]) bool {
+	return !s.disabled && (!s.experimental || g.experimental || experimentalEnv)
+}
+
+// SetStrictPlacement enables or disables strict placement mode. In strict
+// placement mode, placement hints referencing plugins not registered with this
+// group cause a panic when the group's symbols are queried, instead of
+// silently ignoring such placement hints. This catches plugins expected to be
+// present but that simply haven't been linked in.
+func (g *PluginGroup[T]) SetStrictPlacement(strict bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.strict = strict
+}
+
+// SetSortCheck enables or disables checking the results of ordering this
+// plugin group. When enabled, the order is checked to be a fixed point with
+// respect to the placement hints, and to not depend on the registration order
+// of plugins. Any check failure gets logged, or in strict placement mode (see
+// [PluginGroup.SetStrictPlacement]), causes a panic. This is a diagnostic
+// feature for use during development.
+func (g *PluginGroup[T]) SetSortCheck(check bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.sortCheck = check
+}
+
+// Len returns the number of symbols exposed by the plugins in this group.
+func (g *PluginGroup[T]) Len() int {
+	g.lock()
+	defer g.unlock()
+	return len(g.symbols)
+}
+
+// Registrations returns the number of symbol registrations with this group
+// since its creation. In contrast to [PluginGroup.Len], Registrations also
+// counts the registrations of symbols that aren't exposed, such as
+// experimental symbols and default symbols, as well as symbols replacing
+// other symbols, and symbols removed in the meantime.
+func (g *PluginGroup[T]) Registrations() uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.registeredCount
+}
+
+// RemoveMatching removes all symbols from this plugin group whose plugin names
+// satisfy the specified predicate, returning the number of removed symbols.
+func (g *PluginGroup[T]) RemoveMatching(pred func(name string) bool) int {
+	g.mu.Lock()
+	var removed []Symbol[T]
+	remove := func(s Symbol[T]) bool {
+		if !pred(s.Plugin) {
+			return false
+		}
+		removed = append(removed, s)
+		return true
+	}
+	g.symbols = slices.DeleteFunc(g.symbols, remove)
+	g.hidden = slices.DeleteFunc(g.hidden, remove)
+	if len(removed) > 0 {
+		g.ordered = false
+	}
+	g.notify(PluginRemoved, removed...)
+	g.mu.Unlock()
+	finalize(removed)
+	return len(removed)
+}
+
+// Swap exchanges the positions of the two named plugins in the current order
+// of this plugin group, returning false if either plugin doesn't exist. The
+// resulting order is kept as is until the plugin group changes next, such as
+// when registering another symbol, which then causes the plugin group to be
+// sorted again.
+func (g *PluginGroup[T]) Swap(nameA, nameB string) bool {
+	g.mu.Lock()
+	err := g.ensureOrdered()
+	if err != nil {
+		g.mu.Unlock()
+		panic(err)
+	}
+	defer g.mu.Unlock()
+	idxA := slices.IndexFunc(g.symbols, func(s Symbol[T]) bool { return s.Plugin == nameA })
+	idxB := slices.IndexFunc(g.symbols, func(s Symbol[T]) bool { return s.Plugin == nameB })
+	if idxA < 0 || idxB < 0 {
+		return false
+	}
+	g.symbols[idxA], g.symbols[idxB] = g.symbols[idxB], g.symbols[idxA]
+	g.version++
+	return true
+}
+
+// ExportOrder returns the current order of the plugins in this plugin group,
+// for later use with [PluginGroup.ImportOrder], such as when caching the
+// computed plugin order across application restarts.
+func (g *PluginGroup[T]) ExportOrder() []string {
+	return g.Plugins()
+}
+
+// ImportOrder applies a plugin order previously returned by
+// [PluginGroup.ExportOrder], skipping the sorting of this plugin group. If the
+// plugins in the imported order don't match the plugins currently in this
+// plugin group, then ImportOrder returns an error and the plugin group will be
+// freshly sorted instead.
+func (g *PluginGroup[T]) ImportOrder(order []string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.partition()
+	if len(order) != len(g.symbols) {
+		g.ordered = false
+		return fmt.Errorf("cannot import order of %d plugins into group of %d plugins",
+			len(order), len(g.symbols))
+	}
+	positions := make(map[string]int, len(order))
+	for idx, name := range order {
+		if _, ok := positions[name]; ok {
+			g.ordered = false
+			return fmt.Errorf("cannot import order with %w %q", ErrDuplicatePlugin, name)
+		}
+		positions[name] = idx
+	}
+	symbols := make([]Symbol[T], len(g.symbols))
+	for _, symbol := range g.symbols {
+		idx, ok := positions[symbol.Plugin]
+		if !ok {
+			g.ordered = false
+			return fmt.Errorf("cannot import order lacking plugin %q", symbol.Plugin)
+		}
+		symbols[idx] = symbol
+	}
+	g.symbols = symbols
+	g.ordered = true
+	g.version++
+	return nil
+}
+
+// ApplyOrder switches this plugin group into manual-order mode, ordering the
+// plugins as given in the specified list of plugin names, such as when reading
+// the plugin order from a configuration file. Plugins not in the list follow
+// after the listed plugins, keeping their basic order; placement hints are
+// ignored in manual-order mode. Unknown plugin names are ignored, logging a
+// warning. Passing a nil order switches back to ordering by placement hints.
+func (g *PluginGroup[T]) ApplyOrder(order []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := map[string]bool{}
+	for _, symbol := range g.all() {
+		names[symbol.Plugin] = true
+	}
+	for _, name := range order {
+		if !names[name] {
+			log.Printf("plugger: group %s ignoring unknown plugin %q in manual order",
+				groupKeyName(g.SymbolType()), name)
+		}
+	}
+	g.manual = slices.Clone(order)
+	g.ordered = false
+}
+
+// Merge copies all symbols of the other plugin group into this plugin group,
+// subjecting them to the same checks as when registering them with this group
+// directly: this group's validator, [PluginGroup.RequireExplicitName], and
+// [PluginGroup.DeduplicateSymbols]. Symbols the other group inherited from its parent group
+// are not merged. If any of the other group's symbols gets rejected or its
+// plugin name (or plugin ID) collides with the plugins in this group, then
+// Merge returns an error listing the problems and doesn't merge any symbols at
+// all.
+func (g *PluginGroup[T]) Merge(other *PluginGroup[T]) error {
+	// Take a snapshot of the other group first, so we never hold both locks at
+	// the same time and thus cannot deadlock with a concurrent reverse merge.
+	other.mu.RLock()
+	symbols := slices.DeleteFunc(other.all(), func(s Symbol[T]) bool { return s.inherited })
+	other.mu.RUnlock()
+
+	// Validate outside our lock, as validation takes the read lock itself and
+	// the validator might want to query this group too.
+	var errs []error
+	for _, symbol := range symbols {
+		err := func() (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = panicError(p)
+				}
+			}()
+			g.validate(symbol)
+			return nil
+		}()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %q: %w", symbol.Plugin, err))
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.frozen && len(symbols) > 0 {
+		return g.frozenError()
+	}
+	var merged []Symbol[T]
+	registered := g.all()
+	for _, symbol := range symbols {
+		if symbol.derived && g.explicitNames {
+			errs = append(errs, g.explicitNameError())
+			continue
+		}
+		if g.duplicate(symbol) ||
+			(g.dedupe && symbol.lazy == nil && slices.ContainsFunc(merged, func(m Symbol[T]) bool {
+				return m.lazy == nil && sameSymbol(m.S, symbol.S)
+			})) {
+			continue
+		}
+		if err := collision(symbol, registered); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		registered = append(registered, symbol)
+		merged = append(merged, symbol)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot merge plugin groups: %w", errors.Join(errs...))
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	g.ordered = false
+	g.symbols = append(g.symbols, merged...)
+	g.registeredCount += uint64(len(merged))
+	g.signal()
+	g.notify(PluginRegistered, merged...)
+	return nil
+}
+
+// Clear removes all symbols from this plugin group, running the finalizers of
+// all removed symbols and notifying watchers about each removed plugin. This
+// way, hot-reloading code resetting a group doesn't leak plugin resources. See
+// [PluginGroup.ClearQuiet] for a cheap alternative, such as in unit tests.
+func (g *PluginGroup[T]) Clear() {
+	g.mu.Lock()
+	removed := g.all()
+	g.ordered = false
+	g.symbols = nil
+	g.hidden = nil
+	g.notify(PluginRemoved, removed...)
+	g.mu.Unlock()
+	finalize(removed)
+}
+
+// ClearQuiet removes all symbols from this plugin group without running any
+// finalizers and without notifying watchers, such as for quickly resetting a
+// group in unit tests. Use [PluginGroup.Clear] in order to properly clean up
+// the removed plugins.
+func (g *PluginGroup[T]) ClearQuiet() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = false
+	g.symbols = nil
+	g.hidden = nil
+}
+
+// CheckConflicts returns an error if any two plugins registered in this plugin
+// group are mutually exclusive, as declared by either or both plugins using
+// [WithConflicts]. Otherwise, CheckConflicts returns nil. Each conflicting pair
+// of plugins is reported only once.
+func (g *PluginGroup[T]) CheckConflicts() error {
+	g.lock()
+	defer g.unlock()
+
+	names := make(map[string]struct{}, len(g.symbols))
+	for _, symbol := range g.symbols {
+		names[symbol.Plugin] = struct{}{}
+	}
+	reported := map[[2]string]struct{}{}
+	var errs []error
+	for _, symbol := range g.symbols {
+		for _, conflict := range symbol.conflicts {
+			if _, ok := names[conflict]; !ok || conflict == symbol.Plugin {
+				continue
+			}
+			pair := [2]string{symbol.Plugin, conflict}
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if _, ok := reported[pair]; ok {
+				continue
+			}
+			reported[pair] = struct{}{}
+			errs = append(errs, fmt.Errorf("plugins %q and %q conflict", pair[0], pair[1]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TakeOrdered returns the ordered symbols of this plugin group and clears the
+// plugin group in a single atomic step, so that other goroutines cannot observe
+// any intermediate state. As with [PluginGroup.Clear], the finalizers of the
+// removed symbols are run, after the plugin group has been cleared.
+func (g *PluginGroup[T]) TakeOrdered() []T {
+	g.mu.Lock()
+	if err := g.ensureOrdered(); err != nil {
+		g.mu.Unlock()
+		panic(err)
+	}
+	s := make([]T, 0, len(g.symbols))
+	for _, symbol := range g.symbols {
+		s = append(s, symbol.symbol())
+	}
+	removed := g.all()
+	g.ordered = false
+	g.symbols = nil
+	g.hidden = nil
+	g.notify(PluginRemoved, removed...)
+	g.mu.Unlock()
+	finalize(removed)
+	return s
+}
+
+// Backup returns a copy of this plugin group's current plugin configuration,
+// for later restoration using the Restore method. The backup keeps the symbols
+// in their current, and possibly still unsorted, order.
+func (g *PluginGroup[T]) Backup() GroupStash[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return GroupStash[T]{
+		ordered: g.ordered,
+		symbols: slices.Clone(g.symbols),
+		hidden:  slices.Clone(g.hidden),
+	}
+}
+
+// Restore a plugin group's former plugin configuration from a backup previously
+// created by the Backup method, reproducing exactly the order of the symbols at
+// the time of the backup.
+func (g *PluginGroup[T]) Restore(s GroupStash[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ordered = s.ordered
+	g.symbols = slices.Clone(s.symbols)
+	g.hidden = slices.Clone(s.hidden)
+	g.version++
+	g.signal()
+}
+
+// sort the plugins by name and optionally by reference; that is, individual
+// plugins can claim to get to the front/end, or before/after a another named
+// plugin. This method must be called under write lock.
+//
+// The plugin ordering mechanism is with a nod to Jeremy Ruston and his
+// incredible TiddlyWiki (in particular, its list and module sorting).
+func (g *PluginGroup[T]) sort() {
+	g.partition()
+	// First, sort lexicographically by plugin name (not: by plugin path), or
+	// alternatively by registration sequence.
+	order(g.symbols, g.ordering, g.collation)
+	if g.manual != nil {
+		// A manual order overrides all placement hints.
+		orderManually(g.symbols, g.manual)
+		return
+	}
+	// Second, honor the optional positional requests of individual plugins.
+	// Or, at least try to do so...
+	symbols, conflict := resolve(g.placements(g.symbols), g.symbols)
+	g.symbols = symbols
+	if conflict != nil {
+		g.conflict(conflict)
+	}
+}
+
+// placements returns the given symbols with the group's default placement
+// applied to symbols without explicit placements. This method must be called
+// under (read) lock.
+func (g *PluginGroup[T]) placements(symbols []Symbol[T]) []Symbol[T] {
+	if g.defaultPlacement == "" {
+		return symbols
+	}
+	order := slices.Clone(symbols)
+	for idx := range order {
+		if order[idx].Placement == "" {
+			order[idx].Placement = g.defaultPlacement
+		}
+	}
+	return order
+}
+
+// partition the regular and default symbols so that the default symbols are
+// only exposed in the absence of any regular symbols, and otherwise hidden.
+// Experimental symbols are always hidden unless enabled. This method must be
+// called under write lock.
+func (g *PluginGroup[T]) partition() {
+	var regular, defaults, gated []Symbol[T]
+	for _, symbol := range g.all() {
+		if !g.exposed(symbol) {
+			gated = append(gated, symbol)
+			continue
+		}
+		if symbol.fallback {
+			defaults = append(defaults, symbol)
+			continue
+		}
+		regular = append(regular, symbol)
+	}
+	if len(regular) == 0 {
+		g.symbols, g.hidden = defaults, gated
+		return
+	}
+	g.symbols, g.hidden = regular, append(defaults, gated...)
+}
+
+// all returns a fresh list of all registered symbols, including the hidden
+// default symbols, if any. This method must be called under (read) lock.
+func (g *PluginGroup[T]) all() []Symbol[T] {
+	all := make([]Symbol[T], 0, len(g.symbols)+len(g.hidden))
+	return append(append(all, g.symbols...), g.hidden...)
+}
+
+// conflict reports a placement conflict to the registered conflict channel, if
+// any, without blocking. This method must be called under write lock.
+func (g *PluginGroup[T]) conflict(err *PlacementConflict) {
+	if g.conflicts == nil {
+		return
+	}
+	select {
+	case g.conflicts <- err:
+	default:
+	}
+}
+
+// NotifyPlacementConflicts registers the specified channel to receive any
+// [PlacementConflict] errors detected when ordering this group's plugins. The
+// errors are sent without blocking, so if the channel isn't ready to receive,
+// the error gets dropped. Passing nil unregisters any channel.
+func (g *PluginGroup[T]) NotifyPlacementConflicts(ch chan<- error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.conflicts = ch
+}
+
+// lock locks the plugin group against concurrent write changes and sorts the
+// plugin exposed list of symbols, if necessary. The caller needs to (defer to)
+// unlock after having done its work.
+//
+// In strict placement mode, lock panics when placement hints reference unknown
+// plugins; the plugin group is left unlocked in this case.
+func (g *PluginGroup[T]) lock() {
+	g.mu.RLock()
+	// As we cannot downgrade a write lock into a read lock atomatically, we
+	// need to rinse and repeat until got our read lock on a sorted exposed
+	// plugin symbols list...
+	for !g.ordered || g.stale() { // https://github.com/golang/go/issues/4026#issuecomment-66069822
+		g.mu.RUnlock()
+		// Here, another goroutine might win an unintended race with us to sort
+		// the list of exposed plugin symbols, so skip the sort operation if we
+		// finally got the write lock on a sorted list.
+		g.mu.Lock()
+		err := g.ensureOrdered()
+		g.mu.Unlock()
+		if err != nil {
+			panic(err)
+		}
+		// Here, the list might get unsorted again if we're unlucky.
+		g.mu.RLock()
+	}
+	if debug.Load() {
+		g.firstUse.Do(g.logOrder)
+	}
+}
+
+// ensureOrdered sorts the plugin exposed list of symbols, if necessary. In
+// strict placement mode, it returns an error instead if placement hints
+// reference unknown plugins. This method must be called under write lock.
+func (g *PluginGroup[T]) ensureOrdered() error {
+	if g.ordered && !g.stale() {
+		return nil
+	}
+	g.inherit()
+	g.partition()
+	if g.strict && g.manual == nil {
+		if err := unresolvedPlacements(g.symbols, g.defaultPlacement); err != nil {
+			return err
+		}
+	}
+	var unsorted []Symbol[T]
+	sortCheck := g.sortCheck && g.manual == nil
+	if sortCheck {
+		unsorted = slices.Clone(g.symbols)
+	}
+	start := time.Now()
+	g.sort()
+	g.sorts++
+	g.sortTime += time.Since(start)
+	if sortCheck {
+		if err := g.checkSort(unsorted); err != nil {
+			if g.strict {
+				return err
+			}
+			log.Printf("plugger: %s", err.Error())
+		}
+	}
+	g.ordered = true
+	g.version++
+	return nil
+}
+
+// checkSort checks that the current order of symbols is a fixed point, that
+// is, another placement pass doesn't change the order anymore, and that the
+// order doesn't depend on the registration order of the originally unsorted
+// symbols. This method must be called under write lock.
+func (g *PluginGroup[T]) checkSort(unsorted []Symbol[T]) error {
+	sorted := pluginNames(g.symbols)
+	base := slices.Clone(unsorted)
+	order(base, g.ordering, g.collation)
+	again := place(g.placements(base), slices.Clone(g.symbols), nil)
+	if names := pluginNames(again); !slices.Equal(names, sorted) {
+		return fmt.Errorf("plugin order [%s] is not a fixed point, another pass yields [%s]",
+			strings.Join(sorted, ", "), strings.Join(names, ", "))
+	}
+	if g.ordering == OrderByRegistration {
+		return nil
+	}
+	reversed := slices.Clone(unsorted)
+	slices.Reverse(reversed)
+	order(reversed, g.ordering, g.collation)
+	reversed, _ = resolve(g.placements(reversed), reversed)
+	if names := pluginNames(reversed); !slices.Equal(names, sorted) {
+		return fmt.Errorf("plugin order [%s] depends on registration order, reversed registration yields [%s]",
+			strings.Join(sorted, ", "), strings.Join(names, ", "))
 	}
+	return nil
 }
 
 // unlock unlocks the plugin group.
@@ -15,7 +15,19 @@
 package plugger
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -32,6 +44,10 @@ type fooImpl struct{ s string }
 
 func (f fooImpl) Foo() string { return f.s }
 
+type fooStringer struct{ fooImpl }
+
+func (f fooStringer) String() string { return f.s }
+
 var _ = Describe("exposed plugin symbol groups", func() {
 
 	BeforeEach(func() {
@@ -53,6 +69,44 @@ var _ = Describe("exposed plugin symbol groups", func() {
 
 	})
 
+	It("optionally keys groups by type name", func() {
+		groupsByName = map[string]any{}
+		UseNameBasedGroupKeys(true)
+		defer UseNameBasedGroupKeys(false)
+		g := Group[fooFn]()
+		Expect(groupsByName).To(HaveKeyWithValue("github.com/thediveo/go-plugger/v3.fooFn", g))
+		Expect(groups).To(BeEmpty())
+		Expect(Group[fooFn]()).To(BeIdenticalTo(g))
+		Expect(Group[func() string]()).NotTo(BeNil())
+		Expect(groupsByName).To(HaveKey("func() string"))
+		Expect(groupKeyName(reflect.TypeFor[fooIf]())).To(Equal("github.com/thediveo/go-plugger/v3.fooIf"))
+	})
+
+	It("distinguishes groups by type identity", func() {
+		var logs strings.Builder
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+
+		Expect(Group[fooFn]()).NotTo(BeIdenticalTo(Group[func() string]()))
+		Expect(Group[fooFn]().TypeIdentity()).To(MatchRegexp(
+			`^github\.com/thediveo/go-plugger/v3\.fooFn@0x[0-9a-f]+$`))
+		Expect(logs.String()).To(BeEmpty())
+
+		first := func() AnyGroup {
+			type localFn func() string
+			return Group[localFn]()
+		}()
+		second := func() AnyGroup {
+			type localFn func() string
+			return Group[localFn]()
+		}()
+		Expect(first).NotTo(BeIdenticalTo(second))
+		Expect(first.(interface{ TypeIdentity() string }).TypeIdentity()).NotTo(
+			Equal(second.(interface{ TypeIdentity() string }).TypeIdentity()))
+		Expect(logs.String()).To(MatchRegexp(
+			`plugger: distinct symbol types .*\.localFn@0x[0-9a-f]+ and .*\.localFn@0x[0-9a-f]+ share the name .*\.localFn`))
+	})
+
 	It("renders a textual representation of the type and exposed symbols", func() {
 		fooIfGroup := Group[fooIf]()
 		fooIfGroup.Register(&fooImpl{s: "one"}, WithPlugin("one"))
@@ -72,6 +126,15 @@ var _ = Describe("exposed plugin symbol groups", func() {
 				`PluginGroup\[github\.com/thediveo/go-plugger/v3\.barFn\]: \["two":.*\.init\.func.*,"one":.*\.init\.func.*\]`)))
 	})
 
+	It("renders unresolvable func symbols as placeholders", func() {
+		fn := func() string { return "" }
+		Expect(renderSymbol(fn, runtime.FuncForPC)).To(ContainSubstring(".func"))
+		Expect(renderSymbol(fn, func(uintptr) *runtime.Func { return nil })).To(
+			MatchRegexp(`^<func@0x[0-9a-f]+>$`))
+		Expect(renderSymbol(&fooImpl{s: "foo"}, runtime.FuncForPC)).To(
+			Equal(`&plugger.fooImpl{s:"foo"}`))
+	})
+
 	It("doesn't mix exported symbol types", func() {
 		fooGroup := Group[fooFn]()
 		Expect(fooGroup).NotTo(BeNil())
@@ -97,6 +160,448 @@ var _ = Describe("exposed plugin symbol groups", func() {
 		))
 	})
 
+	It("returns symbols except for named plugins", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		syms := g.SymbolsExcept("two", "foo")
+		Expect(syms).To(HaveLen(2))
+		Expect(syms[0]()).To(Equal("one"))
+		Expect(syms[1]()).To(Equal("three"))
+		Expect(g.SymbolsExcept()).To(HaveLen(3))
+	})
+
+	It("returns shuffled symbols without touching the canonical order", func() {
+		g := Group[fooFn]()
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			name := name
+			g.Register(func() string { return name }, WithPlugin(name))
+		}
+		names := func(fns []fooFn) []string {
+			s := make([]string, 0, len(fns))
+			for _, fn := range fns {
+				s = append(s, fn())
+			}
+			return s
+		}
+		canonical := names(g.Symbols())
+		shuffled := names(g.SymbolsShuffled(rand.New(rand.NewSource(42))))
+		Expect(shuffled).To(ConsistOf(canonical))
+		Expect(shuffled).NotTo(Equal(canonical))
+		Expect(names(g.SymbolsShuffled(rand.New(rand.NewSource(42))))).To(Equal(shuffled))
+		Expect(names(g.Symbols())).To(Equal(canonical))
+	})
+
+	It("returns the symbols without copying", func() {
+		g := Group[fooFn]()
+		Expect(g.SymbolsUnsafe()).To(BeEmpty())
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		view := g.SymbolsUnsafe()
+		Expect(view).To(HaveLen(2))
+		Expect(view[0]()).To(Equal("two"))
+		Expect(&g.SymbolsUnsafe()[0]).To(BeIdenticalTo(&view[0]))
+		Expect(testing.AllocsPerRun(10, func() { _ = g.SymbolsUnsafe() })).To(BeZero())
+
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement("<"))
+		Expect(g.SymbolsUnsafe()).To(HaveLen(3))
+		Expect(g.SymbolsUnsafe()[1]()).To(Equal("three"))
+		Expect(view).To(HaveLen(2))
+		Expect(view[0]()).To(Equal("two"))
+	})
+
+	It("returns the first and last symbols", func() {
+		g := Group[fooFn]()
+		fn, ok := g.First()
+		Expect(ok).To(BeFalse())
+		Expect(fn).To(BeNil())
+		fn, ok = g.Last()
+		Expect(ok).To(BeFalse())
+		Expect(fn).To(BeNil())
+
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		fn, ok = g.First()
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("two"))
+		fn, ok = g.Last()
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("three"))
+	})
+
+	It("returns the index of a plugin", func() {
+		g := Group[fooFn]()
+		Expect(g.IndexOf("one")).To(Equal(-1))
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(g.IndexOf("two")).To(Equal(0))
+		Expect(g.IndexOf("one")).To(Equal(1))
+		Expect(g.IndexOf("three")).To(Equal(2))
+		Expect(g.IndexOf("four")).To(Equal(-1))
+	})
+
+	It("returns the top n symbols", func() {
+		g := Group[fooFn]()
+		Expect(g.TopN(3)).To(BeEmpty())
+
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		top := g.TopN(2)
+		Expect(top).To(HaveLen(2))
+		Expect(top[0]()).To(Equal("two"))
+		Expect(top[1]()).To(Equal("one"))
+		Expect(g.TopN(42)).To(HaveLen(3))
+		Expect(g.TopN(0)).To(BeEmpty())
+		Expect(g.TopN(-1)).To(BeEmpty())
+	})
+
+	It("selects the winner", func() {
+		g := Group[fooFn]()
+		_, _, ok := g.Winner()
+		Expect(ok).To(BeFalse())
+		g.Register(func() string { return "enterprise" }, WithPlugin("enterprise"), WithPlacement(">community"))
+		g.Register(func() string { return "community" }, WithPlugin("community"))
+		g.Register(func() string { return "alpha" }, WithPlugin("alpha"))
+		fn, name, ok := g.Winner()
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("enterprise"))
+		Expect(fn()).To(Equal("enterprise"))
+		g.SetWinnerPolicy(FirstWins)
+		_, name, _ = g.Winner()
+		Expect(name).To(Equal("alpha"))
+	})
+
+	It("constructs symbols lazily", func() {
+		g := Group[fooFn]()
+		Expect(func() { g.RegisterFactory("nil", nil) }).To(PanicWith(MatchError(ErrNilSymbol)))
+		Expect(func() {
+			Group[int]().RegisterFactory("int", func() int { return 42 })
+		}).To(PanicWith(MatchError("invalid symbol type: must be func or interface, but got int")))
+
+		constructed := map[string]int{}
+		factory := func(name string) func() fooFn {
+			return func() fooFn {
+				constructed[name]++
+				return func() string { return name }
+			}
+		}
+		g.RegisterFactory("one", factory("one"))
+		g.RegisterFactory("two", factory("two"), WithPlacement("<"))
+		g.RegisterFactory("three", factory("three"))
+		Expect(g.Plugins()).To(Equal([]string{"two", "one", "three"}))
+		Expect(constructed).To(BeEmpty())
+
+		Expect(g.PluginSymbol("one")()).To(Equal("one"))
+		fn, ok := g.First()
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("two"))
+		Expect(g.PluginSymbol("one")()).To(Equal("one"))
+		Expect(constructed).To(Equal(map[string]int{"one": 1, "two": 1}))
+
+		Expect(g.Symbols()).To(HaveLen(3))
+		Expect(g.PluginsSymbols()).To(HaveEach(HaveField("S", Not(BeNil()))))
+		Expect(constructed).To(Equal(map[string]int{"one": 1, "two": 1, "three": 1}))
+	})
+
+	It("initializes plugins in order", func() {
+		g := Group[fooFn]()
+		Expect(g.InitAll()).To(Succeed())
+		var inits []string
+		initializer := func(name string, err error) func() error {
+			return func() error {
+				Expect(g.Len()).To(BeNumerically(">", 0)) // would deadlock if locked
+				inits = append(inits, name)
+				return err
+			}
+		}
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithInit(initializer("one", nil)))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"), WithInit(initializer("two", nil)))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(g.InitAll()).To(Succeed())
+		Expect(inits).To(Equal([]string{"two", "one"}))
+
+		inits = nil
+		g.Register(func() string { return "four" }, WithPlugin("four"), WithPlacement("<one"),
+			WithInit(initializer("four", errors.New("D'OH!"))))
+		Expect(g.InitAll()).To(MatchError(`cannot initialize plugin "four": D'OH!`))
+		Expect(inits).To(Equal([]string{"two", "four"}))
+	})
+
+	It("looks up plugins without sorting", func() {
+		g := Group[fooFn]()
+		g.RegisterDefault(func() string { return "default" }, WithPlugin("default"), WithID(1))
+		Expect(g.PluginSymbol("default")()).To(Equal("default"))
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(2))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		Expect(g.PluginSymbol("one")()).To(Equal("one"))
+		Expect(g.PluginSymbol("default")).To(BeNil())
+		_, ok := g.PluginByID(1)
+		Expect(ok).To(BeFalse())
+		fn, ok := g.PluginByID(2)
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("one"))
+		Expect(g.ordered).To(BeFalse())
+
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(g.RemoveMatching(func(name string) bool { return name != "default" })).To(Equal(2))
+		Expect(g.PluginSymbol("default")()).To(Equal("default"))
+		Expect(g.ordered).To(BeFalse())
+	})
+
+	It("returns plugin configurations", func() {
+		type logConfig struct{ Level int }
+		g := Group[fooFn]()
+		g.Register(func() string { return "log" }, WithPlugin("log"), WithConfig(logConfig{Level: 42}))
+		g.Register(func() string { return "noconf" }, WithPlugin("noconf"))
+		config, ok := PluginConfig[logConfig](g, "log")
+		Expect(ok).To(BeTrue())
+		Expect(config.Level).To(Equal(42))
+		_, ok = PluginConfig[string](g, "log")
+		Expect(ok).To(BeFalse())
+		_, ok = PluginConfig[logConfig](g, "noconf")
+		Expect(ok).To(BeFalse())
+		_, ok = PluginConfig[logConfig](g, "foobar")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("looks up the first plugin matching any name or alias", func() {
+		g := Group[fooFn]()
+		_, _, ok := g.PluginSymbolAny("h2")
+		Expect(ok).To(BeFalse())
+		g.Register(func() string { return "http1" }, WithPlugin("http1"), WithAliases("h1", "http/1.1"))
+		g.Register(func() string { return "http2" }, WithPlugin("http2"), WithAliases("h2", "h2c"))
+		g.Register(func() string { return "h2c" }, WithPlugin("h2c"))
+
+		fn, name, ok := g.PluginSymbolAny("h3", "h2", "h1")
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("h2"))
+		Expect(fn()).To(Equal("http2"))
+
+		fn, name, _ = g.PluginSymbolAny("h2c")
+		Expect(name).To(Equal("h2c"))
+		Expect(fn()).To(Equal("h2c"))
+
+		fn, _, _ = g.PluginSymbolAny("http/1.1")
+		Expect(fn()).To(Equal("http1"))
+		_, _, ok = g.PluginSymbolAny()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("hashes the plugin order", func() {
+		g := Group[fooFn]()
+		empty := g.OrderHash()
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		g.Register(func() string { return "b" }, WithPlugin("b"))
+		hash := g.OrderHash()
+		Expect(hash).NotTo(Equal(empty))
+		Expect(hash).To(Equal(uint64(0xab40d7820d408076)))
+
+		other := &PluginGroup[fooFn]{}
+		other.Register(func() string { return "b" }, WithPlugin("b"))
+		other.Register(func() string { return "a" }, WithPlugin("a"))
+		Expect(other.OrderHash()).To(Equal(hash))
+		Expect(other.Swap("a", "b")).To(BeTrue())
+		Expect(other.OrderHash()).NotTo(Equal(hash))
+
+		other = &PluginGroup[fooFn]{}
+		other.Register(func() string { return "ab" }, WithPlugin("ab"))
+		Expect(other.OrderHash()).NotTo(Equal(hash))
+	})
+
+	It("groups the symbols", func() {
+		g := Group[fooFn]()
+		Expect(g.SymbolsGrouped(func(string) string { return "" })).To(BeEmpty())
+		g.Register(func() string { return "acme/one" }, WithPlugin("acme/one"))
+		g.Register(func() string { return "acme/two" }, WithPlugin("acme/two"), WithPlacement("<"))
+		g.Register(func() string { return "other/three" }, WithPlugin("other/three"))
+		grouped := g.SymbolsGrouped(func(name string) string {
+			vendor, _, _ := strings.Cut(name, "/")
+			return vendor
+		})
+		Expect(grouped).To(HaveLen(2))
+		Expect(grouped["acme"]).To(HaveLen(2))
+		Expect(grouped["acme"][0]()).To(Equal("acme/two"))
+		Expect(grouped["acme"][1]()).To(Equal("acme/one"))
+		Expect(grouped["other"]).To(HaveLen(1))
+	})
+
+	It("picks the best symbol", func() {
+		g := Group[fooFn]()
+		score := func(name string, sym fooFn) int { return len(sym()) }
+		_, _, ok := g.Best(score)
+		Expect(ok).To(BeFalse())
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		fn, name, ok := g.Best(score)
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("two"))
+		Expect(fn()).To(Equal("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		_, name, _ = g.Best(score)
+		Expect(name).To(Equal("three"))
+	})
+
+	It("streams the symbols", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var results []string
+		for symbol := range g.Stream(ctx) {
+			results = append(results, symbol())
+		}
+		Expect(results).To(Equal([]string{"two", "one"}))
+
+		ch := g.Stream(ctx)
+		Expect((<-ch)()).To(Equal("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement(">"))
+		cancel()
+		Eventually(ch).Should(BeClosed())
+	})
+
+	It("ranges over the symbols", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		var ranged []string
+		g.RangeSymbols(func(i int, name string, sym fooFn) bool {
+			Expect(g.Plugins()[i]).To(Equal(name))
+			ranged = append(ranged, sym())
+			return i < 1
+		})
+		Expect(ranged).To(Equal([]string{"two", "one"}))
+	})
+
+	It("exposes default symbols only in absence of regular symbols", func() {
+		g := Group[fooFn]()
+		Expect(g.IsUsingDefault()).To(BeFalse())
+		g.RegisterDefault(func() string { return "builtin" }, WithPlugin("builtin"))
+		Expect(g.IsUsingDefault()).To(BeTrue())
+		Expect(g.Plugins()).To(Equal([]string{"builtin"}))
+		fn, ok := g.First()
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("builtin"))
+
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		Expect(g.IsUsingDefault()).To(BeFalse())
+		Expect(g.Len()).To(Equal(1))
+		Expect(g.Symbols()[0]()).To(Equal("one"))
+
+		backup := g.Backup()
+		Expect(g.RemoveMatching(func(name string) bool { return name == "one" })).To(Equal(1))
+		Expect(g.Plugins()).To(Equal([]string{"builtin"}))
+
+		g.Restore(backup)
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+		g.Clear()
+		Expect(g.Len()).To(BeZero())
+	})
+
+	It("counts all registrations", func() {
+		g := Group[fooFn]()
+		Expect(g.Registrations()).To(BeZero())
+		g.RegisterDefault(func() string { return "builtin" }, WithPlugin("builtin"))
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "exp" }, WithPlugin("exp"), WithExperimental())
+		Expect(g.RegisterWrapping("one", func(prev fooFn) fooFn { return prev })).To(Succeed())
+		Expect(g.Len()).To(Equal(1))
+		Expect(g.Registrations()).To(Equal(uint64(4)))
+		g.Clear()
+		Expect(g.Registrations()).To(Equal(uint64(4)))
+	})
+
+	It("asserts that symbols implement additional interfaces", func() {
+		g := Group[fooIf]()
+		RegisterAssert[fooIf, fmt.Stringer](g, fooStringer{})
+		Expect(g.Plugins()).To(Equal([]string{"go-plugger"}))
+		Expect(func() {
+			RegisterAssert[fooIf, fmt.Stringer](g, &fooImpl{}, WithPlugin("foo"))
+		}).To(PanicWith(MatchError(
+			"symbol must implement fmt.Stringer, but got *plugger.fooImpl")))
+		Expect(g.Len()).To(Equal(1))
+	})
+
+	It("selects plugins with namespaced names", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "acme/codec/zip" }, WithPlugin("acme/codec/zip"))
+		g.Register(func() string { return "acme/codec/tar" }, WithPlugin("acme/codec/tar"),
+			WithPlacement(">acme/codec/zip"))
+		g.Register(func() string { return "acme/codecs" }, WithPlugin("acme/codecs"))
+		g.Register(func() string { return "other/codec" }, WithPlugin("other/codec"))
+		Expect(g.Plugins()).To(Equal([]string{
+			"acme/codec/zip", "acme/codec/tar", "acme/codecs", "other/codec"}))
+		Expect(g.PluginsUnder("acme/codec")).To(Equal([]string{"acme/codec/zip", "acme/codec/tar"}))
+		Expect(g.PluginsUnder("acme/codec/")).To(Equal([]string{"acme/codec/zip", "acme/codec/tar"}))
+		Expect(g.PluginsUnder("acme/codec/zip")).To(Equal([]string{"acme/codec/zip"}))
+		Expect(g.PluginsUnder("")).To(HaveLen(4))
+		Expect(g.PluginsUnder("foo")).To(BeEmpty())
+		syms := g.SelectUnder("acme")
+		Expect(syms).To(HaveLen(3))
+		Expect(syms[2]()).To(Equal("acme/codecs"))
+	})
+
+	It("selects plugins by symbol value", func() {
+		g := Group[any]()
+		g.Register(&fooImpl{s: "foo"}, WithPlugin("foo"))
+		g.Register(func() string { return "bar" }, WithPlugin("bar"))
+		g.Register(&fooImpl{s: "baz"}, WithPlugin("baz"), WithPlacement("<"))
+		syms := g.SelectFunc(func(sym any) bool {
+			_, ok := sym.(fooIf)
+			return ok
+		})
+		Expect(syms).To(HaveExactElements(
+			HaveField("Plugin", "baz"),
+			HaveField("Plugin", "foo"),
+		))
+		Expect(syms[1].S.(fooIf).Foo()).To(Equal("foo"))
+		Expect(g.SelectFunc(func(any) bool { return false })).To(BeEmpty())
+	})
+
+	It("registers symbols with their declared interface type", func() {
+		g := Group[any]()
+		RegisterAs[fooIf](g, &fooImpl{s: "foo"}, WithPlugin("foo"))
+		g.Register(&fooImpl{s: "bar"}, WithPlugin("bar"))
+		Expect(g.PluginsSymbols()).To(HaveExactElements(
+			And(HaveField("Plugin", "bar"), HaveField("Interface", BeNil())),
+			And(HaveField("Plugin", "foo"), HaveField("Interface", reflect.TypeFor[fooIf]())),
+		))
+		Expect(func() { RegisterAs[*fooImpl](g, &fooImpl{}) }).To(PanicWith(MatchError(
+			"declared symbol type must be interface, but got *plugger.fooImpl")))
+		Expect(func() { RegisterAs[fooIf](g, nil) }).To(PanicWith(MatchError(
+			"interface symbol must not be nil")))
+	})
+
+	It("registers symbols reflectively", func() {
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), func() string { return "one" })).To(
+			MatchError("no plugin group for symbol type plugger.fooFn"))
+
+		g := Group[fooFn]()
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), func() string { return "one" },
+			WithPlacement(">"))).To(Succeed())
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), fooFn(func() string { return "two" }),
+			WithPlugin("two"))).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"two", "go-plugger"}))
+		Expect(g.Symbols()[1]()).To(Equal("one"))
+
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), 42)).To(
+			MatchError("invalid symbol type: int is not assignable to plugger.fooFn"))
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), nil)).To(
+			MatchError("invalid symbol type: <nil> is not assignable to plugger.fooFn"))
+		Expect(RegisterReflect(reflect.TypeFor[fooFn](), (func() string)(nil))).To(
+			MatchError("func symbol must not be nil"))
+
+		gi := Group[fooIf]()
+		Expect(RegisterReflect(reflect.TypeFor[fooIf](), &fooImpl{s: "foo"})).To(Succeed())
+		Expect(gi.Symbols()[0].Foo()).To(Equal("foo"))
+	})
+
 	It("finds a specific plugin's symbol", func() {
 		g := Group[fooFn]()
 		Expect(g).NotTo(BeNil())
@@ -107,6 +612,235 @@ var _ = Describe("exposed plugin symbol groups", func() {
 		Expect(foofn()).To(Equal("one"))
 	})
 
+	It("finds plugins by ID and rejects duplicate IDs", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		fn, ok := g.PluginByID(1)
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("one"))
+		_, ok = g.PluginByID(0)
+		Expect(ok).To(BeFalse())
+		_, ok = g.PluginByID(2)
+		Expect(ok).To(BeFalse())
+		Expect(func() {
+			g.Register(func() string { return "three" }, WithPlugin("three"), WithID(1))
+		}).To(PanicWith(MatchError(`duplicate symbol ID 1 for plugins "one" and "three"`)))
+
+		backup := g.Backup()
+		g.Clear()
+		g.Restore(backup)
+		_, ok = g.PluginByID(1)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("reports a plugin's registration source location", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		_, thisfile, thisline, _ := runtime.Caller(0)
+		file, line := g.PluginSource("one")
+		Expect(file).To(Equal(thisfile))
+		Expect(line).To(Equal(thisline - 1))
+		Expect(g.PluginPackage("one")).To(Equal("github.com/thediveo/go-plugger/v3"))
+		Expect(g.PluginPackage("foo")).To(BeEmpty())
+		file, line = g.PluginSource("foo")
+		Expect(file).To(BeEmpty())
+		Expect(line).To(BeZero())
+	})
+
+	It("optionally requires explicit plugin names", func() {
+		g := Group[fooFn]()
+		g.RequireExplicitName(true)
+		Expect(func() {
+			g.Register(func() string { return "one" })
+		}).To(PanicWith(MatchError("explicit plugin name required for group plugger.fooFn")))
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+
+		tx := g.Transaction()
+		tx.Register(func() string { return "two" })
+		Expect(tx.Commit()).To(MatchError(ContainSubstring(
+			"explicit plugin name required for group plugger.fooFn")))
+
+		g.RequireExplicitName(false)
+		g.Register(func() string { return "two" })
+		Expect(g.Plugins()).To(Equal([]string{"go-plugger", "one"}))
+	})
+
+	It("registers symbols only on the allowed platforms", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "os" }, WithPlugin("os"),
+			WithPlatforms("foobar-os", runtime.GOOS))
+		g.Register(func() string { return "arch" }, WithPlugin("arch"),
+			WithPlatforms(runtime.GOARCH))
+		g.Register(func() string { return "pair" }, WithPlugin("pair"),
+			WithPlatforms(runtime.GOOS+"/"+runtime.GOARCH))
+		g.Register(func() string { return "none" }, WithPlugin("none"),
+			WithPlatforms("foobar-os", "foobar-arch"))
+		g.Register(func() string { return "empty" }, WithPlugin("empty"), WithPlatforms())
+
+		tx := g.Transaction()
+		tx.RegisterNamed("tx-none", func() string { return "tx-none" }, WithPlatforms("foobar-os"))
+		Expect(tx.Commit()).To(Succeed())
+
+		Expect(g.Plugins()).To(Equal([]string{"arch", "os", "pair"}))
+	})
+
+	It("gates experimental symbols", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "stable" }, WithPlugin("stable"))
+		g.Register(func() string { return "exp" }, WithPlugin("exp"), WithExperimental())
+		Expect(g.PluginSymbol("exp")).To(BeNil())
+		Expect(g.Plugins()).To(Equal([]string{"stable"}))
+		Expect(g.Symbols()).To(HaveLen(1))
+
+		g.EnableExperimental(true)
+		Expect(g.Plugins()).To(Equal([]string{"exp", "stable"}))
+		Expect(g.PluginSymbol("exp")()).To(Equal("exp"))
+
+		g.EnableExperimental(false)
+		Expect(g.Plugins()).To(Equal([]string{"stable"}))
+
+		defer func(old bool) { experimentalEnv = old }(experimentalEnv)
+		experimentalEnv = true
+		Expect(g.PluginSymbol("exp")()).To(Equal("exp"))
+	})
+
+	It("deduplicates identical symbols", func() {
+		g := Group[any]()
+		fn := func() string { return "fn" }
+		impl := &fooImpl{s: "impl"}
+		g.Register(fn, WithPlugin("fn"))
+		g.Register(fn, WithPlugin("fn-again"))
+		g.Register(impl, WithPlugin("impl"))
+		g.Register(impl, WithPlugin("impl-again"))
+		Expect(g.Len()).To(Equal(4))
+
+		g.Clear()
+		g.DeduplicateSymbols(true)
+		g.Register(fn, WithPlugin("fn"))
+		g.Register(fn, WithPlugin("fn-again"))
+		g.Register(func() string { return "other" }, WithPlugin("other"))
+		g.Register(impl, WithPlugin("impl"))
+		g.Register(impl, WithPlugin("impl-again"))
+		g.Register(&fooImpl{s: "impl"}, WithPlugin("other-impl"))
+		g.Register(fooStringer{fooImpl{s: "foo"}}, WithPlugin("stringer"))
+		g.Register(fooStringer{fooImpl{s: "foo"}}, WithPlugin("stringer-again"))
+		tx := g.Transaction()
+		tx.Register(fn, WithPlugin("tx-fn"))
+		tx.Register(42, WithPlugin("tx-answer"))
+		tx.Register(42, WithPlugin("tx-answer-again"))
+		Expect(tx.Commit()).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"fn", "impl", "other", "other-impl", "stringer", "tx-answer"}))
+	})
+
+	It("rejects duplicate plugin names", func() {
+		g := Group[fooFn]()
+		_, _, line, _ := runtime.Caller(0)
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		line++
+		Expect(func() {
+			g.Register(func() string { return "one" }, WithPlugin("one"))
+		}).To(PanicWith(And(
+			MatchError(ErrDuplicatePlugin),
+			MatchError(MatchRegexp(fmt.Sprintf(
+				`^duplicate plugin "one": first at .*/group_test\.go:%d, again at .*/group_test\.go:%d$`,
+				line, line+3))))))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+	})
+
+	It("doesn't deduplicate distinct closures and method values", func() {
+		g := Group[fooFn]()
+		g.DeduplicateSymbols(true)
+		for _, name := range []string{"a", "b", "c"} {
+			g.Register(func() string { return name }, WithPlugin(name))
+		}
+		x := fooImpl{s: "x"}.Foo
+		g.Register(x, WithPlugin("x"))
+		g.Register(x, WithPlugin("x-again"))
+		g.Register(fooImpl{s: "y"}.Foo, WithPlugin("y"))
+		g.Register(fooImpl{s: "x"}.Foo, WithPlugin("z"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "b", "c", "x", "y", "z"}))
+	})
+
+	It("freezes groups", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		other := &PluginGroup[fooFn]{}
+		other.Register(func() string { return "two" }, WithPlugin("two"))
+		g.Freeze()
+		Expect(func() {
+			g.Register(func() string { return "two" }, WithPlugin("two"))
+		}).To(PanicWith(MatchError("group plugger.fooFn is frozen")))
+		tx := g.Transaction()
+		tx.RegisterNamed("two", func() string { return "two" })
+		Expect(tx.Commit()).To(MatchError(ContainSubstring("group plugger.fooFn is frozen")))
+		Expect(g.Merge(other)).To(MatchError("group plugger.fooFn is frozen"))
+		Expect(g.Merge(&PluginGroup[fooFn]{})).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+
+		bar := Group[barFn]()
+		FreezeAll()
+		Expect(RegisterReflect(reflect.TypeFor[barFn](), func() string { return "bar" })).To(
+			MatchError("group plugger.barFn is frozen"))
+		Expect(bar.Len()).To(BeZero())
+	})
+
+	It("seals groups", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		Expect(g.Seal("two", "one")).To(Succeed())
+		Expect(func() {
+			g.Register(func() string { return "three" }, WithPlugin("three"))
+		}).To(PanicWith(MatchError("group plugger.fooFn is frozen")))
+		Expect(g.Seal("one", "three", "four")).To(MatchError(
+			`group plugger.fooFn lacks required plugins "three", "four"`))
+	})
+
+	It("validates symbols using a group validator", func() {
+		g := Group[fooIf]()
+		g.SetValidator(func(sym fooIf) error {
+			if sym.Foo() == "" {
+				return errors.New("empty foo")
+			}
+			return nil
+		})
+		g.Register(&fooImpl{s: "foo"}, WithPlugin("foo"))
+		Expect(func() {
+			g.Register(&fooImpl{}, WithPlugin("empty"))
+		}).To(PanicWith(MatchError("symbol rejected by validator: empty foo")))
+		Expect(func() {
+			g.Register(nil, WithPlugin("nil"))
+		}).To(PanicWith(MatchError("interface symbol must not be nil")))
+		Expect(RegisterReflect(reflect.TypeFor[fooIf](), &fooImpl{})).To(
+			MatchError("symbol rejected by validator: empty foo"))
+		tx := g.Transaction()
+		tx.RegisterNamed("empty", &fooImpl{})
+		Expect(tx.Commit()).To(MatchError(ContainSubstring("symbol rejected by validator: empty foo")))
+		Expect(g.Plugins()).To(Equal([]string{"foo"}))
+
+		g.SetValidator(nil)
+		g.Register(&fooImpl{}, WithPlugin("empty"))
+		Expect(g.Plugins()).To(Equal([]string{"empty", "foo"}))
+	})
+
+	It("optionally allows typed nil interface symbols", func() {
+		g := Group[fooIf]()
+		var null *fooImpl
+		Expect(func() {
+			g.Register(null, WithPlugin("null"))
+		}).To(PanicWith(MatchError("interface symbol must not be nil")))
+
+		g.AllowNilSymbols(true)
+		g.Register(null, WithPlugin("null"))
+		Expect(func() {
+			g.Register(nil, WithPlugin("untyped"))
+		}).To(PanicWith(MatchError("interface symbol must not be nil")))
+		Expect(g.Symbols()).To(ConsistOf(BeNil()))
+		Expect(g.Symbols()[0]).To(BeAssignableToTypeOf(null))
+	})
+
 	It("fills in the plugin name if missing", func() {
 		g := Group[fooFn]()
 		Expect(g).NotTo(BeNil())
@@ -114,6 +848,22 @@ var _ = Describe("exposed plugin symbol groups", func() {
 		Expect(g.PluginsSymbols()).To(HaveEach(HaveField("Plugin", "go-plugger")))
 	})
 
+	It("warns about derived plugin names", func() {
+		var warned []string
+		WarnOnAutoName(func(name, file string) {
+			warned = append(warned, name+"@"+filepath.Base(file))
+		})
+		defer WarnOnAutoName(nil)
+
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" })
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		tx := g.Transaction()
+		tx.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(tx.Commit()).To(Succeed())
+		Expect(warned).To(Equal([]string{"go-plugger@group_test.go"}))
+	})
+
 	DescribeTable("orders plugins",
 		func(a, ap, b, bp, c, cp string, expected []string) {
 			g := &PluginGroup[any]{
@@ -164,6 +914,549 @@ var _ = Describe("exposed plugin symbol groups", func() {
 			[]string{"alpha", "beta", "gamma"}),
 	)
 
+	It("reports placement conflicts and falls back deterministically", func() {
+		newGroup := func() *PluginGroup[any] {
+			return &PluginGroup[any]{
+				symbols: []Symbol[any]{
+					{Plugin: "alpha", Placement: "<beta"},
+					{Plugin: "beta", Placement: "<gamma"},
+					{Plugin: "gamma", Placement: "<alpha"},
+				},
+			}
+		}
+		g := newGroup()
+		ch := make(chan error, 1)
+		g.NotifyPlacementConflicts(ch)
+		Expect(g.Plugins()).To(Equal([]string{"gamma", "alpha", "beta"}))
+		var err error
+		Expect(ch).To(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring("did not stabilize after 3 passes")))
+		var conflict *PlacementConflict
+		Expect(errors.As(err, &conflict)).To(BeTrue())
+		Expect(conflict.Plugins).To(Equal([]string{"gamma", "alpha", "beta"}))
+
+		g = newGroup()
+		g.symbols[0], g.symbols[2] = g.symbols[2], g.symbols[0]
+		Expect(g.Plugins()).To(Equal([]string{"gamma", "alpha", "beta"}))
+	})
+
+	It("places plugins with the same anchor as a contiguous block", func() {
+		for _, registration := range [][]string{
+			{"a", "b", "c", "foo", "x", "y", "z"},
+			{"z", "y", "x", "foo", "c", "b", "a"},
+			{"foo", "y", "a", "z", "c", "x", "b"},
+		} {
+			g := &PluginGroup[fooFn]{}
+			for _, name := range registration {
+				placement := ""
+				switch name {
+				case "a", "b", "c":
+					placement = ">foo"
+				case "x", "y", "z":
+					placement = "<foo"
+				}
+				g.Register(func() string { return name }, WithPlugin(name), WithPlacement(placement))
+			}
+			Expect(g.Plugins()).To(Equal([]string{"x", "y", "z", "foo", "a", "b", "c"}))
+
+			g.SetOrdering(OrderByRegistration)
+			var before, after []string
+			for _, name := range registration {
+				switch name {
+				case "a", "b", "c":
+					after = append(after, name)
+				case "x", "y", "z":
+					before = append(before, name)
+				}
+			}
+			Expect(g.Plugins()).To(Equal(append(append(before, "foo"), after...)))
+		}
+	})
+
+	It("applies a default placement", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "alpha" }, WithPlugin("alpha"))
+		g.Register(func() string { return "beta" }, WithPlugin("beta"))
+		g.Register(func() string { return "gamma" }, WithPlugin("gamma"), WithPlacement(">"))
+		Expect(g.Plugins()).To(Equal([]string{"alpha", "beta", "gamma"}))
+		g.SetDefaultPlacement("<")
+		Expect(g.Plugins()).To(Equal([]string{"beta", "alpha", "gamma"}))
+		Expect(g.PluginsSymbols()).To(ContainElement(And(
+			HaveField("Plugin", "alpha"), HaveField("Placement", ""))))
+		g.SetDefaultPlacement("")
+		Expect(g.Plugins()).To(Equal([]string{"alpha", "beta", "gamma"}))
+	})
+
+	It("places plugins next to other plugins", func() {
+		g := Group[fooFn]()
+		for _, name := range []string{"a", "benc", "c", "d"} {
+			g.Register(func() string { return name }, WithPlugin(name))
+		}
+		g.Register(func() string { return "zdec" }, WithPlugin("zdec"), WithPlacement("~benc"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "benc", "zdec", "c", "d"}))
+		g.Register(func() string { return "aa" }, WithPlugin("aa"), WithPlacement("~d"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "benc", "zdec", "c", "aa", "d"}))
+		g.Register(func() string { return "b" }, WithPlugin("b"), WithPlacement("~nope"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "b", "benc", "zdec", "c", "aa", "d"}))
+		Expect(g.Audit()).To(ConsistOf(HaveField("Kind", AuditDanglingPlacement)))
+		Expect(g.ValidateGraph()).To(MatchError(ContainSubstring(
+			`plugin "b" references unknown plugin in placement "~nope"`)))
+	})
+
+	It("places plugins with positional offsets independent of their starting side", func() {
+		for _, tc := range []struct {
+			name, placement string
+			expected        []string
+		}{
+			{"c", ">a+2", []string{"a", "b", "d", "c", "e"}},
+			{"e", ">a+2", []string{"a", "b", "c", "e", "d"}},
+			{"b", ">a+2", []string{"a", "c", "d", "b", "e"}},
+			{"d", "<e-2", []string{"a", "d", "b", "c", "e"}},
+			{"b", "<e-2", []string{"a", "b", "c", "d", "e"}},
+			{"a", "<e-2", []string{"b", "a", "c", "d", "e"}},
+			{"c", "<e-1", []string{"a", "b", "c", "d", "e"}},
+			{"a", ">e+1", []string{"b", "c", "d", "e", "a"}},
+		} {
+			g := &PluginGroup[fooFn]{}
+			for _, name := range []string{"a", "b", "c", "d", "e"} {
+				placement := ""
+				if name == tc.name {
+					placement = tc.placement
+				}
+				g.Register(func() string { return name }, WithPlugin(name), WithPlacement(placement))
+			}
+			Expect(g.Plugins()).To(Equal(tc.expected), "%s with %s", tc.name, tc.placement)
+		}
+	})
+
+	It("places plugins with positional offsets", func() {
+		g := Group[fooFn]()
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			g.Register(func() string { return name }, WithPlugin(name))
+		}
+		g.Register(func() string { return "x" }, WithPlugin("x"), WithPlacement(">a+2"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "b", "c", "x", "d", "e"}))
+		g.Register(func() string { return "y" }, WithPlugin("y"), WithPlacement("<e-1"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "b", "c", "x", "y", "d", "e"}))
+
+		g.Clear()
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		g.Register(func() string { return "b-1" }, WithPlugin("b-1"))
+		g.Register(func() string { return "c" }, WithPlugin("c"), WithPlacement("<b-1"))
+		g.Register(func() string { return "x" }, WithPlugin("x"), WithPlacement("<a-42"))
+		g.Register(func() string { return "y" }, WithPlugin("y"), WithPlacement("<a+foo"))
+		Expect(g.Plugins()).To(Equal([]string{"x", "a", "c", "b-1", "y"}))
+		Expect(g.Audit()).To(ConsistOf(HaveField("Plugins", []string{"y"})))
+	})
+
+	It("doesn't resolve hyphenated unknown plugin names to other plugins", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "foo" }, WithPlugin("foo"))
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		g.Register(func() string { return "z" }, WithPlugin("z"), WithPlacement("<foo-bar"))
+		Expect(g.Plugins()).To(Equal([]string{"a", "foo", "z"}))
+		g.SetStrictPlacement(true)
+		Expect(func() { g.Plugins() }).To(PanicWith(And(
+			MatchError(ErrUnresolvedPlacement),
+			MatchError(ContainSubstring(`plugin "z" references unknown plugin in placement "<foo-bar"`)))))
+	})
+
+	It("checks for conflicting plugins", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "mysql" }, WithPlugin("mysql"),
+			WithConflicts("postgres", "sqlite", "mysql"))
+		Expect(g.CheckConflicts()).To(Succeed())
+		g.Register(func() string { return "postgres" }, WithPlugin("postgres"),
+			WithConflicts("mysql"))
+		g.Register(func() string { return "sqlite" }, WithPlugin("sqlite"))
+		err := g.CheckConflicts()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			"plugins \"mysql\" and \"postgres\" conflict\nplugins \"mysql\" and \"sqlite\" conflict"))
+	})
+
+	It("takes the ordered symbols, clearing the group", func() {
+		g := Group[fooFn]()
+		Expect(g.TakeOrdered()).To(BeEmpty())
+		finalized := 0
+		g.Register(func() string { return "one" }, WithPlugin("one"),
+			WithFinalizer(func() { finalized++ }))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		g.RegisterDefault(func() string { return "default" }, WithPlugin("default"))
+		taken := g.TakeOrdered()
+		Expect(taken).To(HaveLen(2))
+		Expect(taken[0]()).To(Equal("two"))
+		Expect(taken[1]()).To(Equal("one"))
+		Expect(finalized).To(Equal(1))
+		Expect(g.Len()).To(BeZero())
+	})
+
+	It("removes matching plugins", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "test-two" }, WithPlugin("test-two"), WithPlacement("<"))
+		g.Register(func() string { return "test-three" }, WithPlugin("test-three"))
+		Expect(g.RemoveMatching(func(name string) bool {
+			return strings.HasPrefix(name, "test-")
+		})).To(Equal(2))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+		Expect(g.RemoveMatching(func(string) bool { return false })).To(BeZero())
+	})
+
+	It("returns the unordered symbols", func() {
+		g := Group[fooFn]()
+		g.RegisterDefault(func() string { return "default" }, WithPlugin("default"))
+		Expect(g.Unordered()).To(HaveExactElements(HaveField("Plugin", "default")))
+
+		g.Register(func() string { return "c" }, WithPlugin("c"))
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		g.Register(func() string { return "b" }, WithPlugin("b"), WithPlacement("<"))
+		Expect(g.Plugins()).To(Equal([]string{"b", "a", "c"}))
+		Expect(pluginNames(g.Unordered())).To(Equal([]string{"c", "a", "b"}))
+	})
+
+	It("exposes the registration sequence", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "b" }, WithPlugin("b"))
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		syms := g.PluginsSymbols()
+		Expect(syms).To(HaveLen(2))
+		Expect(syms[0].Plugin).To(Equal("a"))
+		Expect(syms[0].Seq).To(BeNumerically(">", syms[1].Seq))
+		Expect(syms[1].Seq).NotTo(BeZero())
+	})
+
+	It("orders by registration", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "gamma" }, WithPlugin("gamma"))
+		g.Register(func() string { return "alpha" }, WithPlugin("alpha"))
+		g.Register(func() string { return "delta" }, WithPlugin("delta"), WithPlacement("<"))
+		g.Register(func() string { return "beta" }, WithPlugin("beta"))
+		Expect(g.Plugins()).To(Equal([]string{"delta", "alpha", "beta", "gamma"}))
+		g.SetOrdering(OrderByRegistration)
+		Expect(g.Plugins()).To(Equal([]string{"delta", "gamma", "alpha", "beta"}))
+		g.SetOrdering(OrderByName)
+		Expect(g.Plugins()).To(Equal([]string{"delta", "alpha", "beta", "gamma"}))
+	})
+
+	It("orders by collation", func() {
+		g := Group[fooFn]()
+		for _, name := range []string{"beta", "Zulu", "élan", "Alpha", "echo"} {
+			g.Register(func() string { return name }, WithPlugin(name))
+		}
+		Expect(g.Plugins()).To(Equal([]string{"Alpha", "Zulu", "beta", "echo", "élan"}))
+		g.SetCollation(CollateCaseInsensitive)
+		Expect(g.Plugins()).To(Equal([]string{"Alpha", "beta", "echo", "Zulu", "élan"}))
+		g.SetCollation(CollateUnicode)
+		Expect(g.Plugins()).To(Equal([]string{"Alpha", "beta", "echo", "élan", "Zulu"}))
+		g.SetCollation(CollateBytes)
+		Expect(g.Plugins()).To(Equal([]string{"Alpha", "Zulu", "beta", "echo", "élan"}))
+	})
+
+	It("orders by sort keys", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "zulu" }, WithPlugin("Zulu Plugin"), WithSortKey("01-zulu"))
+		g.Register(func() string { return "alpha" }, WithPlugin("Alpha Plugin"), WithSortKey("02-alpha"))
+		g.Register(func() string { return "beta" }, WithPlugin("Beta Plugin"))
+		g.Register(func() string { return "echo" }, WithPlugin("Echo Plugin"), WithPlacement("<Alpha Plugin"))
+		Expect(g.Plugins()).To(Equal([]string{"Zulu Plugin", "Echo Plugin", "Alpha Plugin", "Beta Plugin"}))
+	})
+
+	It("panics on unresolved placements in strict mode", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithPlacement("<two"))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+		g.SetStrictPlacement(true)
+		Expect(func() { g.Plugins() }).To(PanicWith(MatchError(
+			`unresolved placements: plugin "one" references unknown plugin in placement "<two"`)))
+		Expect(func() { g.Plugins() }).To(Panic())
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		Expect(g.Plugins()).To(Equal([]string{"one", "two"}))
+		g.SetStrictPlacement(false)
+		Expect(g.RemoveMatching(func(name string) bool { return name == "two" })).To(Equal(1))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+	})
+
+	It("wraps plugin symbols", func() {
+		g := Group[fooFn]()
+		Expect(g.RegisterWrapping("one", func(prev fooFn) fooFn { return prev })).To(MatchError(
+			`cannot wrap unknown plugin "one"`))
+
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithPlacement("<"), WithID(42))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		decorate := func(prev fooFn) fooFn {
+			return func() string { return "(" + prev() + ")" }
+		}
+		Expect(g.RegisterWrapping("one", decorate)).To(Succeed())
+		Expect(g.RegisterWrapping("one", decorate, WithPlacement(">"))).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(g.PluginSymbol("one")()).To(Equal("((one))"))
+		_, ok := g.PluginByID(42)
+		Expect(ok).To(BeTrue())
+		file, _ := g.PluginSource("one")
+		Expect(file).To(HaveSuffix("/group_test.go"))
+
+		Expect(g.RegisterWrapping("two", func(fooFn) fooFn { return nil })).To(MatchError(
+			`cannot wrap plugin "two": func symbol must not be nil`))
+		Expect(g.PluginSymbol("two")()).To(Equal("two"))
+	})
+
+	It("runs finalizers outside the lock when removing symbols", func() {
+		g := Group[fooFn]()
+		var finalized []string
+		finalizer := func(name string) func() {
+			return func() {
+				Expect(g.Len()).To(BeNumerically(">=", 0)) // would deadlock if locked
+				finalized = append(finalized, name)
+			}
+		}
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithFinalizer(finalizer("one")))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithFinalizer(finalizer("two")))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(g.RemoveMatching(func(name string) bool { return name == "two" })).To(Equal(1))
+		Expect(finalized).To(Equal([]string{"two"}))
+
+		restore := StashGroups(reflect.TypeFor[fooFn]())
+		Expect(g.Len()).To(BeZero())
+		restore()
+		Expect(finalized).To(Equal([]string{"two"}))
+
+		g.Clear()
+		Expect(finalized).To(Equal([]string{"two", "one"}))
+	})
+
+	It("clears quietly", func() {
+		g := Group[fooFn]()
+		var finalized []string
+		g.Register(func() string { return "one" }, WithPlugin("one"),
+			WithFinalizer(func() { finalized = append(finalized, "one") }))
+		ch := g.Watch()
+		defer g.StopWatch(ch)
+		g.ClearQuiet()
+		Expect(g.Len()).To(BeZero())
+		Expect(finalized).To(BeEmpty())
+		Expect(ch).NotTo(Receive())
+	})
+
+	It("swaps plugins until the next change", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(g.Swap("one", "foo")).To(BeFalse())
+		Expect(g.Swap("one", "two")).To(BeTrue())
+		Expect(g.Plugins()).To(Equal([]string{"two", "three", "one"}))
+		Expect(g.Plugins()).To(Equal([]string{"two", "three", "one"}))
+		g.Register(func() string { return "four" }, WithPlugin("four"))
+		Expect(g.Plugins()).To(Equal([]string{"four", "one", "three", "two"}))
+	})
+
+	It("exports and imports plugin orders", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement("<"))
+		order := g.ExportOrder()
+		Expect(order).To(Equal([]string{"three", "one", "two"}))
+
+		Expect(g.ImportOrder([]string{"two", "three", "one"})).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"two", "three", "one"}))
+		Expect(g.Symbols()[0]()).To(Equal("two"))
+
+		Expect(g.ImportOrder([]string{"two", "three"})).To(MatchError(
+			"cannot import order of 2 plugins into group of 3 plugins"))
+		Expect(g.Plugins()).To(Equal(order))
+		Expect(g.ImportOrder([]string{"two", "two", "one"})).To(MatchError(
+			`cannot import order with duplicate plugin "two"`))
+		Expect(g.ImportOrder([]string{"two", "four", "one"})).To(MatchError(
+			`cannot import order lacking plugin "three"`))
+		Expect(g.Plugins()).To(Equal(order))
+	})
+
+	It("applies manual plugin orders", func() {
+		var logs strings.Builder
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+
+		g := Group[fooFn]()
+		g.Register(func() string { return "a" }, WithPlugin("a"))
+		g.Register(func() string { return "b" }, WithPlugin("b"))
+		g.Register(func() string { return "c" }, WithPlugin("c"), WithPlacement("<"))
+		g.Register(func() string { return "d" }, WithPlugin("d"))
+		Expect(g.Plugins()).To(Equal([]string{"c", "a", "b", "d"}))
+
+		g.ApplyOrder([]string{"d", "foo", "b"})
+		Expect(logs.String()).To(ContainSubstring(`ignoring unknown plugin "foo" in manual order`))
+		Expect(g.Plugins()).To(Equal([]string{"d", "b", "a", "c"}))
+
+		g.Register(func() string { return "e" }, WithPlugin("e"), WithPlacement("<"))
+		Expect(g.Plugins()).To(Equal([]string{"d", "b", "a", "c", "e"}))
+
+		g.ApplyOrder(nil)
+		Expect(g.Plugins()).To(Equal([]string{"e", "c", "a", "b", "d"}))
+	})
+
+	It("merges plugin groups", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+		other := &PluginGroup[fooFn]{}
+		other.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		Expect(g.Merge(other)).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(other.Plugins()).To(Equal([]string{"two"}))
+
+		other.Register(func() string { return "three" }, WithPlugin("three"), WithID(1))
+		err := g.Merge(other)
+		Expect(err).To(MatchError(ErrDuplicatePlugin))
+		Expect(err).To(MatchError(ErrDuplicateSymbol))
+		Expect(err).To(MatchError(MatchRegexp(
+			`^cannot merge plugin groups: duplicate plugin "two": first at .*/group_test\.go:\d+, again at .*/group_test\.go:\d+\n` +
+				`duplicate symbol ID 1 for plugins "one" and "three"$`)))
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+	})
+
+	It("validates merged symbols", func() {
+		g := &PluginGroup[fooFn]{}
+		g.SetValidator(func(fn fooFn) error {
+			if fn() == "bad" {
+				return errors.New("bad symbol")
+			}
+			return nil
+		})
+		other := &PluginGroup[fooFn]{}
+		other.Register(func() string { return "good" }, WithPlugin("good"))
+		other.Register(func() string { return "bad" }, WithPlugin("bad"))
+		err := g.Merge(other)
+		Expect(err).To(MatchError(ErrRejected))
+		Expect(err).To(MatchError(
+			`cannot merge plugin groups: plugin "bad": symbol rejected by validator: bad symbol`))
+		Expect(g.Plugins()).To(BeEmpty())
+	})
+
+	It("requires explicit plugin names of merged symbols", func() {
+		g := &PluginGroup[fooFn]{}
+		g.RequireExplicitName(true)
+		other := &PluginGroup[fooFn]{}
+		other.Register(func() string { return "foo" })
+		Expect(g.Merge(other)).To(MatchError(ErrExplicitNameRequired))
+		Expect(g.Plugins()).To(BeEmpty())
+	})
+
+	It("deduplicates merged symbols", func() {
+		fn := func() string { return "foo" }
+		g := &PluginGroup[fooFn]{}
+		g.DeduplicateSymbols(true)
+		g.Register(fn, WithPlugin("one"))
+		other := &PluginGroup[fooFn]{}
+		other.Register(fn, WithPlugin("two"))
+		other.Register(func() string { return "bar" }, WithPlugin("three"))
+		Expect(g.Merge(other)).To(Succeed())
+		Expect(g.Plugins()).To(ConsistOf("one", "three"))
+	})
+
+	It("doesn't merge inherited symbols", func() {
+		parent := &PluginGroup[fooFn]{}
+		parent.Register(func() string { return "a" }, WithPlugin("a"))
+		other := parent.Derive()
+		other.Register(func() string { return "b" }, WithPlugin("b"))
+		Expect(other.Plugins()).To(ConsistOf("a", "b"))
+		g := &PluginGroup[fooFn]{}
+		Expect(g.Merge(other)).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"b"}))
+	})
+
+	It("sorts idempotently and independent of the registration order", func() {
+		type reg struct{ name, placement string }
+		regs := []reg{
+			{"a", ">foo"},
+			{"foo", ""},
+			{"b", "<a"},
+			{"c", ""},
+			{"d", "<"},
+			{"e", ">"},
+		}
+		var permute func(prefix, rest []reg, fn func([]reg))
+		permute = func(prefix, rest []reg, fn func([]reg)) {
+			if len(rest) == 0 {
+				fn(prefix)
+				return
+			}
+			for idx := range rest {
+				remaining := append(append([]reg{}, rest[:idx]...), rest[idx+1:]...)
+				permute(append(prefix[:len(prefix):len(prefix)], rest[idx]), remaining, fn)
+			}
+		}
+		var reference []string
+		permutations := 0
+		permute(nil, regs, func(regs []reg) {
+			permutations++
+			g := &PluginGroup[fooFn]{}
+			for _, r := range regs {
+				g.Register(func() string { return r.name }, WithPlugin(r.name), WithPlacement(r.placement))
+				// Force a (re)sort after each registration, as in incrementally
+				// built groups.
+				plugins := g.Plugins()
+				if slices.Contains(plugins, "a") && slices.Contains(plugins, "foo") {
+					Expect(slices.Index(plugins, "a")).To(BeNumerically(">", slices.Index(plugins, "foo")),
+						"registration order %v", regs)
+				}
+			}
+			plugins := g.Plugins()
+			if reference == nil {
+				reference = plugins
+			}
+			Expect(plugins).To(Equal(reference), "registration order %v", regs)
+
+			g.mu.Lock()
+			g.sort()
+			g.mu.Unlock()
+			Expect(pluginNames(g.symbols)).To(Equal(reference), "re-sorting %v", regs)
+		})
+		Expect(permutations).To(Equal(720))
+		Expect(reference).To(Equal([]string{"d", "c", "foo", "b", "a", "e"}))
+	})
+
+	It("checks sort results", func() {
+		var logs strings.Builder
+		log.SetOutput(&logs)
+		defer log.SetOutput(os.Stderr)
+
+		g := &PluginGroup[any]{
+			symbols: []Symbol[any]{
+				{Plugin: "alpha", Placement: "<beta"},
+				{Plugin: "beta", Placement: "<gamma"},
+				{Plugin: "gamma", Placement: "<alpha"},
+			},
+		}
+		g.SetSortCheck(true)
+		Expect(g.Plugins()).To(Equal([]string{"gamma", "alpha", "beta"}))
+		Expect(logs.String()).To(ContainSubstring(
+			"plugger: plugin order [gamma, alpha, beta] is not a fixed point"))
+		g.SetStrictPlacement(true)
+		Expect(func() { g.Plugins() }).To(PanicWith(MatchError(ContainSubstring("is not a fixed point"))))
+
+		g = &PluginGroup[any]{
+			symbols: []Symbol[any]{
+				{Plugin: "alpha", Placement: "<"},
+				{Plugin: "beta"},
+				{Plugin: "alpha", Placement: ">"},
+			},
+		}
+		g.SetSortCheck(true)
+		g.SetStrictPlacement(true)
+		Expect(func() { g.Plugins() }).To(PanicWith(MatchError(ContainSubstring("depends on registration order"))))
+
+		g = &PluginGroup[any]{
+			symbols: []Symbol[any]{
+				{Plugin: "beta", Placement: "<"},
+				{Plugin: "alpha", Placement: ">beta"},
+			},
+		}
+		g.SetSortCheck(true)
+		g.SetStrictPlacement(true)
+		Expect(g.Plugins()).To(Equal([]string{"beta", "alpha"}))
+	})
+
 	It("backs up and restores", func() {
 		g := Group[fooFn]()
 		Expect(g).NotTo(BeNil())
@@ -190,4 +1483,32 @@ var _ = Describe("exposed plugin symbol groups", func() {
 		Expect(g.Plugins()).To(ConsistOf("two", "one"))
 	})
 
+	It("round-trips the symbol order through backup and restore", func() {
+		g := Group[fooFn]()
+		g.RegisterDefault(func() string { return "default" }, WithPlugin("default"))
+		g.Register(func() string { return "c" }, WithPlugin("c"))
+		g.Register(func() string { return "a" }, WithPlugin("a"), WithPlacement(">b"))
+		g.Register(func() string { return "b" }, WithPlugin("b"))
+		unsorted := func() []string {
+			g.mu.RLock()
+			defer g.mu.RUnlock()
+			return pluginNames(g.all())
+		}
+		before := unsorted()
+		backup := g.Backup()
+		g.Restore(backup)
+		Expect(unsorted()).To(Equal(before))
+		sorted := g.Plugins()
+
+		backup = g.Backup()
+		g.Clear()
+		g.Restore(backup)
+		g.mu.RLock()
+		ordered := g.ordered
+		g.mu.RUnlock()
+		Expect(ordered).To(BeTrue())
+		Expect(g.Plugins()).To(Equal(sorted))
+		Expect(unsorted()).To(Equal(append(slices.Clone(sorted), "default")))
+	})
+
 })
@@ -0,0 +1,41 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import "context"
+
+// HealthChecker is optionally implemented by plugin symbols in order to report
+// their health when probed by [PluginGroup.HealthCheck].
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheck probes the health of all plugins in this Group, in order,
+// returning the per-plugin results keyed by plugin name. Symbols implementing
+// [HealthChecker] get their HealthCheck method called with ctx; all other
+// symbols are skipped and reported as healthy with a nil error. HealthCheck
+// works on a snapshot of this Group's symbols, so health checks can safely
+// access this Group.
+func (g *PluginGroup[T]) HealthCheck(ctx context.Context) map[string]error {
+	results := map[string]error{}
+	for _, symbol := range g.PluginsSymbols() {
+		var err error
+		if checker, ok := any(symbol.S).(HealthChecker); ok {
+			err = checker.HealthCheck(ctx)
+		}
+		results[symbol.Plugin] = err
+	}
+	return results
+}
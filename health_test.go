@@ -0,0 +1,65 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type healthyIf interface{ Name() string }
+
+type healthy struct {
+	name string
+	err  error
+}
+
+func (h *healthy) Name() string { return h.name }
+
+func (h *healthy) HealthCheck(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return h.err
+}
+
+type unchecked struct{}
+
+func (unchecked) Name() string { return "unchecked" }
+
+var _ = Describe("plugin health checks", func() {
+
+	It("probes plugins implementing HealthChecker", func() {
+		g := &PluginGroup[healthyIf]{}
+		Expect(g.HealthCheck(context.Background())).To(BeEmpty())
+
+		g.Register(&healthy{name: "ok"}, WithPlugin("ok"))
+		g.Register(&healthy{name: "sick", err: errors.New("D'OH!")}, WithPlugin("sick"))
+		g.Register(unchecked{}, WithPlugin("unchecked"))
+		results := g.HealthCheck(context.Background())
+		Expect(results).To(HaveLen(3))
+		Expect(results).To(HaveKeyWithValue("ok", BeNil()))
+		Expect(results).To(HaveKeyWithValue("sick", MatchError("D'OH!")))
+		Expect(results).To(HaveKeyWithValue("unchecked", BeNil()))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(g.HealthCheck(ctx)).To(HaveKeyWithValue("ok", MatchError(context.Canceled)))
+	})
+
+})
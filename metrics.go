@@ -0,0 +1,91 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SortStats informs about how often a plugin group has sorted its symbols and
+// how much time it spent doing so. Frequent sorting indicates churning
+// registrations, such as registering plugins after their plugin group has
+// already been used, hurting read latency.
+type SortStats struct {
+	Sorts   uint64        // number of times the symbols have been sorted.
+	Total   time.Duration // cumulative time spent sorting.
+	Average time.Duration // average time spent per sort, or zero.
+}
+
+// EnableMetrics enables counting how many times the symbols of individual
+// plugins have been returned by this plugin group's single-symbol accessors,
+// that is, [PluginGroup.PluginSymbol], [PluginGroup.PluginByID],
+// [PluginGroup.First], [PluginGroup.Last], and [PluginGroup.Winner]. Enabling
+// metrics again leaves the existing counters untouched.
+func (g *PluginGroup[T]) EnableMetrics() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.metrics == nil {
+		g.metrics = &sync.Map{}
+	}
+}
+
+// PluginMetrics returns how many times the symbols of the individual plugins
+// have been returned by this plugin group's single-symbol accessors since
+// enabling metrics using [PluginGroup.EnableMetrics]. PluginMetrics returns
+// nil if metrics haven't been enabled.
+func (g *PluginGroup[T]) PluginMetrics() map[string]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.metrics == nil {
+		return nil
+	}
+	metrics := map[string]int{}
+	g.metrics.Range(func(key, value any) bool {
+		metrics[key.(string)] = int(value.(*atomic.Int64).Load())
+		return true
+	})
+	return metrics
+}
+
+// count another use of the named plugin's symbol, if metrics are enabled.
+// This method must be called under (read) lock.
+func (g *PluginGroup[T]) count(name string) {
+	if g.metrics == nil {
+		return
+	}
+	counter, ok := g.metrics.Load(name)
+	if !ok {
+		counter, _ = g.metrics.LoadOrStore(name, &atomic.Int64{})
+	}
+	counter.(*atomic.Int64).Add(1)
+}
+
+// SortStats returns how many times this plugin group has sorted its symbols
+// since its creation, together with the cumulative and average time spent
+// sorting.
+func (g *PluginGroup[T]) SortStats() SortStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	stats := SortStats{
+		Sorts: g.sorts,
+		Total: g.sortTime,
+	}
+	if g.sorts > 0 {
+		stats.Average = g.sortTime / time.Duration(g.sorts)
+	}
+	return stats
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin usage metrics", func() {
+
+	It("doesn't count unless enabled", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		_, _ = g.First()
+		Expect(g.PluginMetrics()).To(BeNil())
+	})
+
+	It("counts symbol uses", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.EnableMetrics()
+		Expect(g.PluginMetrics()).To(BeEmpty())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = g.First()
+				_, _ = g.Last()
+			}()
+		}
+		wg.Wait()
+		_ = g.PluginSymbol("one")
+		_ = g.PluginSymbol("foo")
+		_, _ = g.PluginByID(1)
+		_, _, _ = g.Winner()
+		g.EnableMetrics()
+		Expect(g.PluginMetrics()).To(Equal(map[string]int{"one": 12, "two": 11}))
+	})
+
+	It("reports sort statistics", func() {
+		g := &PluginGroup[fooFn]{}
+		Expect(g.SortStats()).To(BeZero())
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		_ = g.Plugins()
+		_ = g.Plugins()
+		Expect(g.SortStats().Sorts).To(Equal(uint64(1)))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		_ = g.Plugins()
+		stats := g.SortStats()
+		Expect(stats.Sorts).To(Equal(uint64(2)))
+		Expect(stats.Average).To(Equal(stats.Total / 2))
+	})
+
+})
@@ -0,0 +1,91 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	runtimedebug "runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PluginModule returns the path of the Go module the plugin identified by its
+// name has been registered from, or "" if either no such named plugin exists in
+// this symbol group or its package is unknown. The module path is derived on a
+// best-effort basis from the import path of the registering package, either
+// matching the modules listed in the binary's build information, or otherwise
+// assuming hosting-style module paths, such as "github.com/foo/bar/v2".
+func (g *PluginGroup[T]) PluginModule(name string) string {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	symbol, _ := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	return modulePath(symbol.Package, buildModules())
+}
+
+// buildModules returns the paths of the main module and its dependencies as
+// recorded in the binary's build information, if available.
+var buildModules = sync.OnceValue(func() []string {
+	info, ok := runtimedebug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	modules := []string{info.Main.Path}
+	for _, dep := range info.Deps {
+		modules = append(modules, dep.Path)
+	}
+	return modules
+})
+
+// modulePath returns the path of the module of the specified package import
+// path, preferring the longest of the specified module paths the package
+// belongs to. If there is no such module, modulePath falls back to the first
+// three path elements of a package import path with a dotted domain name,
+// plus an optional major version suffix, and otherwise to the first path
+// element.
+func modulePath(pkg string, modules []string) string {
+	if pkg == "" {
+		return ""
+	}
+	module := ""
+	for _, path := range modules {
+		if path != "" && len(path) > len(module) &&
+			(pkg == path || strings.HasPrefix(pkg, path+"/")) {
+			module = path
+		}
+	}
+	if module != "" {
+		return module
+	}
+	elems := strings.Split(pkg, "/")
+	if !strings.Contains(elems[0], ".") {
+		return elems[0] // such as a standard library package or a local module.
+	}
+	n := min(3, len(elems))
+	if n < len(elems) && majorVersion(elems[n]) {
+		n++
+	}
+	return strings.Join(elems[:n], "/")
+}
+
+// majorVersion returns true if the specified path element is a major version
+// suffix, such as "v2".
+func majorVersion(elem string) bool {
+	if !strings.HasPrefix(elem, "v") {
+		return false
+	}
+	n, err := strconv.ParseUint(elem[1:], 10, 64)
+	return err == nil && n >= 2
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin modules", func() {
+
+	DescribeTable("derives module paths from package paths",
+		func(pkg string, modules []string, expected string) {
+			Expect(modulePath(pkg, modules)).To(Equal(expected))
+		},
+		Entry(nil, "", nil, ""),
+		Entry(nil, "example.org/foo/bar/baz", []string{"example.org/foo", "example.org/foo/bar"},
+			"example.org/foo/bar"),
+		Entry(nil, "example.org/foo/barbaz", []string{"example.org/foo/bar"},
+			"example.org/foo/barbaz"),
+		Entry(nil, "github.com/foo/bar/baz", nil, "github.com/foo/bar"),
+		Entry(nil, "github.com/foo/bar/v2/baz", nil, "github.com/foo/bar/v2"),
+		Entry(nil, "github.com/foo/bar/v1/baz", nil, "github.com/foo/bar"),
+		Entry(nil, "example.org/foo", nil, "example.org/foo"),
+		Entry(nil, "mymodule/internal/foo", nil, "mymodule"),
+	)
+
+	It("reports the module of a plugin", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "foo" }, WithPlugin("foo"))
+		Expect(g.PluginModule("foo")).To(Equal("github.com/thediveo/go-plugger/v3"))
+		Expect(g.PluginModule("bar")).To(BeEmpty())
+	})
+
+})
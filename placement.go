@@ -0,0 +1,362 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// PlacementConflict is reported when the placement hints of the plugins in a
+// group cannot be resolved into a stable order within a bounded number of
+// passes, such as when placement hints form a cycle.
+type PlacementConflict struct {
+	Plugins []string // fallback order of plugins, after the first pass.
+	Passes  int      // number of placement passes carried out.
+}
+
+// Error returns a textual description of the placement conflict.
+func (e *PlacementConflict) Error() string {
+	return fmt.Sprintf("plugin placement did not stabilize after %d passes, falling back to %s",
+		e.Passes, strings.Join(e.Plugins, ", "))
+}
+
+// Ordering specifies the basic order of the symbols in a plugin group, before
+// applying the placement hints.
+type Ordering int
+
+// The supported basic orderings of plugin group symbols.
+const (
+	OrderByName         Ordering = iota // order lexicographically by plugin name (default).
+	OrderByRegistration                 // order by registration sequence.
+)
+
+// Collation specifies how plugin names get compared when ordering the symbols
+// of a plugin group by name.
+type Collation int
+
+// The supported plugin name collations.
+const (
+	CollateBytes           Collation = iota // compare plugin names byte-wise (default).
+	CollateCaseInsensitive                  // compare plugin names case-insensitively.
+	CollateUnicode                          // compare plugin names using the Unicode collation algorithm.
+)
+
+// less returns a comparison function for plugin names according to the
+// collation. Plugin names comparing as equal are additionally compared
+// byte-wise in order to keep the ordering deterministic.
+func (c Collation) less() func(a, b string) bool {
+	switch c {
+	case CollateCaseInsensitive:
+		return func(a, b string) bool {
+			if la, lb := strings.ToLower(a), strings.ToLower(b); la != lb {
+				return la < lb
+			}
+			return a < b
+		}
+	case CollateUnicode:
+		// A Collator isn't safe for concurrent use, so we need a fresh one.
+		collator := collate.New(language.Und)
+		return func(a, b string) bool {
+			if c := collator.CompareString(a, b); c != 0 {
+				return c < 0
+			}
+			return a < b
+		}
+	default:
+		return func(a, b string) bool { return a < b }
+	}
+}
+
+// order the given symbols in place according to the specified basic ordering,
+// comparing plugin names using the specified collation.
+func order[T any](symbols []Symbol[T], ordering Ordering, collation Collation) {
+	switch ordering {
+	case OrderByRegistration:
+		sort.SliceStable(symbols, func(a, b int) bool {
+			return symbols[a].Seq < symbols[b].Seq
+		})
+	default:
+		// Sort lexicographically by plugin name (not: by plugin path), or by
+		// the optional sort keys instead, falling back to the plugin names
+		// for identical sort keys.
+		less := collation.less()
+		sort.Slice(symbols, func(a, b int) bool {
+			if ka, kb := symbols[a].sortKey(), symbols[b].sortKey(); ka != kb {
+				return less(ka, kb)
+			}
+			return less(symbols[a].Plugin, symbols[b].Plugin)
+		})
+	}
+}
+
+// orderManually reorders the given symbols to follow the specified list of
+// plugin names. Symbols of plugins not in the list follow after the listed
+// plugins, keeping their relative order.
+func orderManually[T any](symbols []Symbol[T], names []string) {
+	positions := make(map[string]int, len(names))
+	for idx, name := range names {
+		if _, ok := positions[name]; !ok {
+			positions[name] = idx
+		}
+	}
+	position := func(s Symbol[T]) int {
+		if pos, ok := positions[s.Plugin]; ok {
+			return pos
+		}
+		return len(names)
+	}
+	sort.SliceStable(symbols, func(a, b int) bool {
+		return position(symbols[a]) < position(symbols[b])
+	})
+}
+
+// resolve the placements of the given symbols, processing the placements in the
+// given order. As placements might depend on each other, resolve repeats
+// placing until the order doesn't change anymore, but only for a bounded
+// number of passes. If the order doesn't stabilize, resolve falls back to the
+// order after the first pass and additionally returns the conflict.
+func resolve[T any](order []Symbol[T], symbols []Symbol[T]) ([]Symbol[T], *PlacementConflict) {
+	if len(symbols) == 0 {
+		return symbols, nil
+	}
+	first := place(order, slices.Clone(symbols), nil)
+	resolved := first
+	for pass := 1; pass <= len(symbols); pass++ {
+		next := place(order, slices.Clone(resolved), nil)
+		if slices.Equal(pluginNames(next), pluginNames(resolved)) {
+			return resolved, nil
+		}
+		resolved = next
+	}
+	return first, &PlacementConflict{
+		Plugins: pluginNames(first),
+		Passes:  len(symbols),
+	}
+}
+
+// place carries out a single placement pass, honoring the optional positional
+// requests of individual plugins in the given order, acting on the symbols
+// list and returning it. If onMove isn't nil, it gets called for each plugin
+// actually changing its position, with its index before and after the move.
+//
+// Multiple plugins with the same placement relative to the same named plugin,
+// such as "<foo", are placed as a contiguous block, keeping their relative
+// order from the given order.
+func place[T any](order []Symbol[T], symbols []Symbol[T], onMove func(symbol Symbol[T], from, to int)) []Symbol[T] {
+	// blocks maps placements relative to named plugins to the last plugin
+	// placed so far with exactly this placement.
+	blocks := map[string]string{}
+	for _, symbol := range order {
+		// Find the next plugin to process from the original list on in the
+		// current and potentially modified list, because we need to work on the
+		// current list when shuffling plugins around.
+		var idx int
+		var sym Symbol[T]
+		for idx, sym = range symbols {
+			if sym.Plugin == symbol.Plugin {
+				break
+			}
+		}
+		pos := idx // start with no change in a plugin's sequence position
+		anchored := false
+		// Does the plugin want to be positioned either before a specifically
+		// named other plugin or at the beginning?
+		if strings.HasPrefix(symbol.Placement, "<") {
+			before := symbol.Placement[1:]
+			if before == "" {
+				pos = 0 // tangarines FIRST (*all* of them, *snicker*)
+			} else if i, offset, ok := placementIndex(symbols, before); ok {
+				// Find the named plugin at its current position; not at the
+				// original position, that wouldn't make sense and mix up the
+				// original intention.
+				pos = relativePosition(idx, i, offset, len(symbols))
+				anchored = true
+			}
+		}
+		// Does the plugin want to be positioned either after another
+		// specifically named plugin or at the end of the sequence?
+		if strings.HasPrefix(symbol.Placement, ">") {
+			after := symbol.Placement[1:]
+			if after == "" {
+				pos = len(symbols)
+			} else if i, offset, ok := placementIndex(symbols, after); ok {
+				// Find the named plugin at its current position; not at the
+				// original position, that wouldn't make sense and mix up the
+				// original intention.
+				pos = relativePosition(idx, i, 1+offset, len(symbols))
+				anchored = true
+			}
+		}
+		// Does the plugin want to be positioned next to another specifically
+		// named plugin, but doesn't care about the side? Then stay on the side
+		// the plugin currently is on, as this moves the fewest other plugins.
+		if strings.HasPrefix(symbol.Placement, "~") {
+			if i, _, ok := placementIndex(symbols, symbol.Placement[1:]); ok && i != idx {
+				pos = i
+				if idx > i {
+					pos = i + 1
+				}
+				anchored = true
+			}
+		}
+		// Keep plugins with the same placement relative to the same named
+		// plugin together in a block, following the block's previous plugin.
+		if anchored {
+			if prev, ok := blocks[symbol.Placement]; ok {
+				if i := slices.IndexFunc(symbols, func(s Symbol[T]) bool { return s.Plugin == prev }); i >= 0 {
+					pos = i + 1
+				}
+			}
+			blocks[symbol.Placement] = symbol.Plugin
+		}
+		symbols = move(symbols, idx, pos)
+		if onMove != nil {
+			to := pos
+			if idx < pos {
+				to-- // as the plugin got removed before reinserting it.
+			}
+			if to != idx {
+				onMove(symbol, idx, to)
+			}
+		}
+	}
+	return symbols
+}
+
+// hasAnchor returns true if the specified placement references a named plugin,
+// such as "<foo", ">foo", or "~foo".
+func hasAnchor(placement string) bool {
+	return len(placement) >= 2 && strings.ContainsRune("<>~", rune(placement[0]))
+}
+
+// placementIndex returns the current index of the plugin referenced by the
+// given placement reference (without the leading "<", ">", or "~"), together with
+// the optional positional offset of the reference, such as "foo+2" or "bar-1".
+// If the referenced plugin cannot be found, placementIndex returns false.
+func placementIndex[T any](symbols []Symbol[T], ref string) (idx int, offset int, ok bool) {
+	_, offset, ok = placementRef(ref, func(name string) bool {
+		idx = slices.IndexFunc(symbols, func(s Symbol[T]) bool { return s.Plugin == name })
+		return idx >= 0
+	})
+	return idx, offset, ok
+}
+
+// placementRef returns the name of the plugin referenced by the given
+// placement reference (without the leading "<", ">", or "~"), together with the
+// optional positional offset of the reference, and whether the plugin is known.
+// A reference exactly naming a known plugin always takes precedence, so plugin
+// names containing "+" or "-" keep working. Otherwise, a trailing "+n" or "-n"
+// is taken as the positional offset, but only if n is a valid number, so that
+// references to unknown hyphenated plugin names, such as "foo-bar", don't
+// accidentally reference another plugin, such as "foo".
+func placementRef(ref string, known func(name string) bool) (name string, offset int, ok bool) {
+	if known(ref) {
+		return ref, 0, true
+	}
+	sign := strings.LastIndexAny(ref, "+-")
+	if sign <= 0 {
+		return ref, 0, false
+	}
+	n, err := strconv.ParseUint(ref[sign+1:], 10, 31)
+	if err != nil || !known(ref[:sign]) {
+		return ref, 0, false
+	}
+	offset = int(n)
+	if ref[sign] == '-' {
+		offset = -offset
+	}
+	return ref[:sign], offset, true
+}
+
+// relativePosition returns the position to move the plugin currently at index
+// idx to, so that it ends up at the specified distance from the anchor plugin
+// currently at index anchor, clamped to the beginning and end. A distance of 0
+// places the plugin directly before the anchor, and 1 directly after it. The
+// distance is measured on the list without the plugin being moved, so that the
+// result doesn't depend on which side of the anchor the plugin started out.
+func relativePosition(idx, anchor, distance, length int) int {
+	if anchor == idx {
+		return idx // a plugin placing itself relative to itself stays put.
+	}
+	if anchor > idx {
+		anchor-- // as the plugin gets removed first.
+	}
+	slot := clamp(anchor+distance, length-1)
+	if slot >= idx {
+		return slot + 1 // as move takes the position before removing the plugin.
+	}
+	return slot
+}
+
+// clamp the given position to the range [0, max].
+func clamp(pos int, max int) int {
+	if pos < 0 {
+		return 0
+	}
+	if pos > max {
+		return max
+	}
+	return pos
+}
+
+// unresolvedPlacements returns an error if any of the placement hints of the
+// given symbols references a plugin not present in the list of symbols,
+// otherwise nil. Symbols without explicit placement hints are checked using the
+// default placement hint instead.
+func unresolvedPlacements[T any](symbols []Symbol[T], defaultPlacement string) error {
+	names := map[string]struct{}{}
+	for _, symbol := range symbols {
+		names[symbol.Plugin] = struct{}{}
+	}
+	var unresolved []string
+	for _, symbol := range symbols {
+		placement := symbol.Placement
+		if placement == "" {
+			placement = defaultPlacement
+		}
+		if !hasAnchor(placement) {
+			continue
+		}
+		if _, _, ok := placementRef(placement[1:], func(name string) bool {
+			_, ok := names[name]
+			return ok
+		}); ok {
+			continue
+		}
+		unresolved = append(unresolved,
+			fmt.Sprintf("plugin %q references unknown plugin in placement %q",
+				symbol.Plugin, placement))
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnresolvedPlacement, strings.Join(unresolved, "; "))
+}
+
+// pluginNames returns the plugin names of the given symbols, in order.
+func pluginNames[T any](symbols []Symbol[T]) []string {
+	names := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		names = append(names, symbol.Plugin)
+	}
+	return names
+}
@@ -0,0 +1,13 @@
+/*
+Package pluggertest helps unit tests to work with isolated plugin groups,
+without having to poke at plugger's internals or forgetting to clean up.
+
+	func TestFoo(t *testing.T) {
+	    g := pluggertest.GuardGroup[plugin.DoItFn](t)
+	    g.Register(...)
+	    // ...
+	}
+
+Ginkgo users can pass GinkgoT() instead of a [testing.T].
+*/
+package pluggertest
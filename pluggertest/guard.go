@@ -0,0 +1,50 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluggertest
+
+import (
+	"reflect"
+
+	"github.com/thediveo/go-plugger/v3"
+)
+
+// TB is the subset of [testing.TB] required for guarding plugin groups; it is
+// satisfied by [testing.T], [testing.B], as well as Ginkgo's GinkgoT().
+type TB interface {
+	Helper()
+	Cleanup(func())
+}
+
+// GuardGroup backs up and clears the plugin group for the symbol type T,
+// registering a cleanup function with t that restores the plugin group's
+// original configuration when the test finishes. GuardGroup returns the
+// (cleared) plugin group.
+func GuardGroup[T any](t TB) *plugger.PluginGroup[T] {
+	t.Helper()
+	g := plugger.Group[T]()
+	backup := g.Backup()
+	g.Restore(plugger.GroupStash[T]{})
+	t.Cleanup(func() { g.Restore(backup) })
+	return g
+}
+
+// GuardGroups backs up and clears the plugin groups for the specified symbol
+// types, registering a cleanup function with t that restores the plugin groups'
+// original configurations when the test finishes. Use [reflect.TypeFor] to get
+// the symbol types.
+func GuardGroups(t TB, types ...reflect.Type) {
+	t.Helper()
+	t.Cleanup(plugger.StashGroups(types...))
+}
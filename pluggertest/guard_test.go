@@ -0,0 +1,78 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluggertest
+
+import (
+	"reflect"
+
+	"github.com/thediveo/go-plugger/v3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fooFn func() string
+type barFn func() string
+type bazFn func() string
+
+type mockedT struct {
+	cleanups []func()
+}
+
+func (t *mockedT) Helper() {}
+
+func (t *mockedT) Cleanup(fn func()) { t.cleanups = append(t.cleanups, fn) }
+
+func (t *mockedT) finish() {
+	for idx := len(t.cleanups) - 1; idx >= 0; idx-- {
+		t.cleanups[idx]()
+	}
+}
+
+var _ = Describe("guarding plugin groups", func() {
+
+	It("guards a single plugin group", func() {
+		plugger.Group[fooFn]().Register(func() string { return "one" }, plugger.WithPlugin("one"))
+		defer plugger.Group[fooFn]().Clear()
+
+		t := &mockedT{}
+		g := GuardGroup[fooFn](t)
+		Expect(g).To(BeIdenticalTo(plugger.Group[fooFn]()))
+		Expect(g.Plugins()).To(BeEmpty())
+		g.Register(func() string { return "two" }, plugger.WithPlugin("two"))
+		t.finish()
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+	})
+
+	It("guards multiple plugin groups", func() {
+		plugger.Group[fooFn]().Register(func() string { return "one" }, plugger.WithPlugin("one"))
+		defer plugger.Group[fooFn]().Clear()
+
+		t := &mockedT{}
+		GuardGroups(t, reflect.TypeFor[fooFn](), reflect.TypeFor[barFn](), reflect.TypeFor[bazFn]())
+		Expect(plugger.Group[fooFn]().Plugins()).To(BeEmpty())
+		plugger.Group[fooFn]().Register(func() string { return "two" }, plugger.WithPlugin("two"))
+		plugger.Group[barFn]().Register(func() string { return "three" }, plugger.WithPlugin("three"))
+		t.finish()
+		Expect(plugger.Group[fooFn]().Plugins()).To(Equal([]string{"one"}))
+		Expect(plugger.Group[barFn]().Plugins()).To(BeEmpty())
+	})
+
+	It("works with GinkgoT", func() {
+		g := GuardGroup[bazFn](GinkgoT())
+		g.Register(func() string { return "one" }, plugger.WithPlugin("one"))
+	})
+
+})
@@ -0,0 +1,50 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluggertest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thediveo/go-plugger/v3"
+)
+
+// AssertOrder checks that the plugins of the plugin group for the symbol type
+// T are in exactly the expected order, returning a descriptive error
+// otherwise.
+func AssertOrder[T any](expected ...string) error {
+	actual := plugger.Group[T]().Plugins()
+	idx := 0
+	for ; idx < len(expected) && idx < len(actual); idx++ {
+		if expected[idx] != actual[idx] {
+			break
+		}
+	}
+	if idx == len(expected) && idx == len(actual) {
+		return nil
+	}
+	var diff string
+	switch {
+	case idx == len(expected):
+		diff = fmt.Sprintf("unexpected plugin %q at index %d", actual[idx], idx)
+	case idx == len(actual):
+		diff = fmt.Sprintf("missing plugin %q at index %d", expected[idx], idx)
+	default:
+		diff = fmt.Sprintf("expected plugin %q at index %d, but got %q",
+			expected[idx], idx, actual[idx])
+	}
+	return fmt.Errorf("plugin order mismatch: %s\n  expected: [%s]\n  actual:   [%s]",
+		diff, strings.Join(expected, ", "), strings.Join(actual, ", "))
+}
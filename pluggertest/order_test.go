@@ -0,0 +1,49 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluggertest
+
+import (
+	"github.com/thediveo/go-plugger/v3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("asserting plugin orders", func() {
+
+	BeforeEach(func() {
+		g := GuardGroup[fooFn](GinkgoT())
+		g.Register(func() string { return "one" }, plugger.WithPlugin("one"))
+		g.Register(func() string { return "two" }, plugger.WithPlugin("two"), plugger.WithPlacement("<"))
+	})
+
+	It("accepts the expected order", func() {
+		Expect(AssertOrder[fooFn]("two", "one")).To(Succeed())
+	})
+
+	DescribeTable("describes mismatches",
+		func(expected []string, msg string) {
+			Expect(AssertOrder[fooFn](expected...)).To(MatchError(HavePrefix(msg)))
+		},
+		Entry("different", []string{"one", "two"},
+			`plugin order mismatch: expected plugin "one" at index 0, but got "two"`+
+				"\n  expected: [one, two]\n  actual:   [two, one]"),
+		Entry("missing", []string{"two", "one", "three"},
+			`plugin order mismatch: missing plugin "three" at index 2`),
+		Entry("unexpected", []string{"two"},
+			`plugin order mismatch: unexpected plugin "one" at index 1`),
+	)
+
+})
@@ -0,0 +1,65 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+// ReadOnlyGroup is a read-only view onto a [PluginGroup], exposing only the
+// query methods but none of the methods that modify the group, such as
+// [PluginGroup.Register] and [PluginGroup.Clear]. Passing a ReadOnlyGroup to
+// plugin consumer code thus ensures at the type level that the consumers
+// cannot accidentally mutate the group.
+type ReadOnlyGroup[T any] struct {
+	g *PluginGroup[T]
+}
+
+// ReadOnly returns a read-only view onto this plugin group. The view always
+// reflects the current state of the plugin group, including any registrations
+// made after the view has been created.
+func (g *PluginGroup[T]) ReadOnly() *ReadOnlyGroup[T] {
+	return &ReadOnlyGroup[T]{g: g}
+}
+
+// String renders a textual representation of the underlying plugin group.
+func (r *ReadOnlyGroup[T]) String() string {
+	return r.g.String()
+}
+
+// Symbols returns all symbols exposed by the plugins in the underlying plugin
+// group. See also [PluginGroup.Symbols].
+func (r *ReadOnlyGroup[T]) Symbols() []T {
+	return r.g.Symbols()
+}
+
+// PluginsSymbols returns all exposed symbols together with the names of the
+// plugins exposing them. See also [PluginGroup.PluginsSymbols].
+func (r *ReadOnlyGroup[T]) PluginsSymbols() []Symbol[T] {
+	return r.g.PluginsSymbols()
+}
+
+// PluginSymbol returns the exposed symbol of the plugin identified by its name,
+// or the zero symbol value. See also [PluginGroup.PluginSymbol].
+func (r *ReadOnlyGroup[T]) PluginSymbol(name string) T {
+	return r.g.PluginSymbol(name)
+}
+
+// Plugins returns the names of all plugins exposing symbols in the underlying
+// plugin group. See also [PluginGroup.Plugins].
+func (r *ReadOnlyGroup[T]) Plugins() []string {
+	return r.g.Plugins()
+}
+
+// Len returns the number of exposed symbols in the underlying plugin group.
+func (r *ReadOnlyGroup[T]) Len() int {
+	return r.g.Len()
+}
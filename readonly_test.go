@@ -0,0 +1,47 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("read-only plugin group views", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+	})
+
+	It("queries the underlying group", func() {
+		g := Group[fooFn]()
+		ro := g.ReadOnly()
+		Expect(ro.Len()).To(BeZero())
+
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<one"))
+		Expect(ro.Len()).To(Equal(2))
+		Expect(ro.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(ro.Symbols()).To(HaveLen(2))
+		Expect(ro.Symbols()[0]()).To(Equal("two"))
+		Expect(ro.PluginsSymbols()).To(HaveEach(HaveField("S", Not(BeNil()))))
+		Expect(ro.PluginSymbol("one")()).To(Equal("one"))
+		Expect(ro.PluginSymbol("foo")).To(BeNil())
+		Expect(ro.String()).To(Equal(g.String()))
+	})
+
+})
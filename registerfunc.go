@@ -0,0 +1,39 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+// RegisterFunc0 registers a plugin-exposed function without parameters with
+// the plugin group for the func() R symbol type, inferring the result type R
+// from the function. It is a shorthand for Group[func() R]().Register(fn,
+// opts...), without having to spell out the function type.
+func RegisterFunc0[R any](fn func() R, opts ...RegisterOption) {
+	Group[func() R]().registerSymbol(fn, opts, 1)
+}
+
+// RegisterFunc1 registers a plugin-exposed function with a single parameter
+// with the plugin group for the func(A) R symbol type, inferring the parameter
+// and result types from the function. It is a shorthand for Group[func(A)
+// R]().Register(fn, opts...).
+func RegisterFunc1[A, R any](fn func(A) R, opts ...RegisterOption) {
+	Group[func(A) R]().registerSymbol(fn, opts, 1)
+}
+
+// RegisterFunc2 registers a plugin-exposed function with two parameters with
+// the plugin group for the func(A, B) R symbol type, inferring the parameter
+// and result types from the function. It is a shorthand for Group[func(A, B)
+// R]().Register(fn, opts...).
+func RegisterFunc2[A, B, R any](fn func(A, B) R, opts ...RegisterOption) {
+	Group[func(A, B) R]().registerSymbol(fn, opts, 1)
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("registering funcs", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+	})
+
+	It("infers the func symbol types", func() {
+		RegisterFunc0(func() int { return 42 }, WithPlugin("zero"))
+		RegisterFunc1(func(s string) int { return len(s) }, WithPlugin("one"))
+		RegisterFunc1(strconv.Itoa, WithPlugin("itoa"))
+		RegisterFunc2(func(a, b int) int { return a + b })
+
+		Expect(Group[func() int]().PluginSymbol("zero")()).To(Equal(42))
+		Expect(Group[func(string) int]().PluginSymbol("one")("abc")).To(Equal(3))
+		Expect(Group[func(int) string]().PluginSymbol("itoa")(42)).To(Equal("42"))
+
+		symbols := Group[func(int, int) int]().PluginsSymbols()
+		Expect(symbols).To(HaveLen(1))
+		Expect(symbols[0].Plugin).To(Equal("go-plugger"))
+		Expect(filepath.Base(symbols[0].File)).To(Equal("registerfunc_test.go"))
+		Expect(symbols[0].S(1, 2)).To(Equal(3))
+	})
+
+})
@@ -0,0 +1,148 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// Registrar stages multiple symbol registrations for a particular
+// [PluginGroup] and then either applies them all at once using
+// [Registrar.Commit], or discards them using [Registrar.Abort]. Use
+// [PluginGroup.Transaction] to get a new Registrar.
+type Registrar[T any] struct {
+	g      *PluginGroup[T]
+	mu     sync.Mutex  // protects the following elements.
+	staged []Symbol[T] // staged symbols, not yet registered.
+	errs   []error     // errors encountered while staging symbols.
+}
+
+// Transaction returns a new [Registrar] for staging multiple registrations that
+// then get committed atomically to this plugin group.
+func (g *PluginGroup[T]) Transaction() *Registrar[T] {
+	return &Registrar[T]{g: g}
+}
+
+// Register stages a plugin-exposed symbol, with optional additional
+// registration information. In contrast to [PluginGroup.Register], an invalid
+// symbol doesn't panic, but instead causes [Registrar.Commit] to fail.
+func (r *Registrar[T]) Register(symbol T, opts ...RegisterOption) {
+	s := Symbol[T]{S: symbol}
+	for _, option := range opts {
+		option(&s)
+	}
+	r.stage(s, 1)
+}
+
+// RegisterNamed stages a plugin-exposed symbol for the explicitly named plugin,
+// with optional additional registration information.
+func (r *Registrar[T]) RegisterNamed(name string, symbol T, opts ...RegisterOption) {
+	s := Symbol[T]{S: symbol, Plugin: name}
+	for _, option := range opts {
+		option(&s)
+	}
+	r.stage(s, 1)
+}
+
+// stage validates the specified symbol, completing it as necessary, and then
+// adds it to the staged symbols. Any validation error is recorded for later
+// reporting by Commit.
+func (r *Registrar[T]) stage(s Symbol[T], offset int) {
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = panicError(p)
+			}
+		}()
+		r.g.validate(s)
+		s.complete(offset+2, runtime.Caller)
+		return nil
+	}()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	s.warnAutoName()
+	r.staged = append(r.staged, s)
+}
+
+// Commit validates the staged symbols as a whole and then registers them
+// atomically with the plugin group. If any staged symbol was invalid, or the
+// staged symbols contain duplicate plugin names or IDs either among themselves
+// or with respect to the plugins already registered in the group, then Commit
+// returns an error and doesn't register any of the staged symbols. In any case,
+// the Registrar is empty afterwards and can be reused.
+func (r *Registrar[T]) Commit() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	staged, errs := r.staged, r.errs
+	r.staged, r.errs = nil, nil
+
+	r.g.mu.Lock()
+	defer r.g.mu.Unlock()
+	if r.g.frozen && len(staged) > 0 {
+		return fmt.Errorf("cannot commit registrations: %w", r.g.frozenError())
+	}
+	var kept []Symbol[T]
+	staged = slices.DeleteFunc(staged, func(s Symbol[T]) bool {
+		if !s.supported() || r.g.duplicate(s) ||
+			(r.g.dedupe && slices.ContainsFunc(kept, func(k Symbol[T]) bool { return sameSymbol(k.S, s.S) })) {
+			return true
+		}
+		kept = append(kept, s)
+		return false
+	})
+	registered := r.g.all()
+	for _, symbol := range staged {
+		if symbol.derived && r.g.explicitNames {
+			errs = append(errs, r.g.explicitNameError())
+			continue
+		}
+		if err := collision(symbol, registered); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		registered = append(registered, symbol)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot commit registrations: %w", errors.Join(errs...))
+	}
+	if len(staged) == 0 {
+		return nil
+	}
+	for idx := range staged {
+		staged[idx].Seq = registrations.Add(1)
+	}
+	r.g.ordered = false
+	r.g.symbols = append(r.g.symbols, staged...)
+	r.g.registeredCount += uint64(len(staged))
+	r.g.signal()
+	r.g.notify(PluginRegistered, staged...)
+	return nil
+}
+
+// Abort discards all staged symbols.
+func (r *Registrar[T]) Abort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.staged, r.errs = nil, nil
+}
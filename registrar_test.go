@@ -0,0 +1,88 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"reflect"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("transactional registration", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+	})
+
+	It("commits staged registrations", func() {
+		g := Group[fooFn]()
+		tx := g.Transaction()
+		tx.Register(func() string { return "auto" })
+		tx.RegisterNamed("two", func() string { return "two" }, WithPlacement("<"))
+		Expect(g.Len()).To(BeZero())
+		Expect(tx.Commit()).To(Succeed())
+		Expect(g.Plugins()).To(Equal([]string{"two", "go-plugger"}))
+		Expect(tx.Commit()).To(Succeed())
+		Expect(g.Len()).To(Equal(2))
+	})
+
+	It("aborts staged registrations", func() {
+		g := Group[fooFn]()
+		tx := g.Transaction()
+		tx.RegisterNamed("one", func() string { return "one" })
+		tx.Abort()
+		Expect(tx.Commit()).To(Succeed())
+		Expect(g.Len()).To(BeZero())
+	})
+
+	It("rejects the whole batch when a symbol is invalid", func() {
+		g := Group[fooFn]()
+		tx := g.Transaction()
+		tx.RegisterNamed("one", func() string { return "one" })
+		tx.RegisterNamed("two", nil)
+		Expect(tx.Commit()).To(MatchError(ContainSubstring("func symbol must not be nil")))
+		Expect(g.Len()).To(BeZero())
+	})
+
+	It("rejects duplicate plugin names", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+
+		tx := g.Transaction()
+		tx.RegisterNamed("two", func() string { return "two" })
+		_, _, line, _ := runtime.Caller(0)
+		tx.RegisterNamed("two", func() string { return "two" })
+		Expect(tx.Commit()).To(MatchError(MatchRegexp(
+			`duplicate plugin "two": first at .*/registrar_test\.go:%d, again at .*/registrar_test\.go:%d`,
+			line-1, line+1)))
+
+		tx.RegisterNamed("one", func() string { return "one" })
+		Expect(tx.Commit()).To(MatchError(ContainSubstring(`duplicate plugin "one"`)))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+	})
+
+	It("rejects duplicate plugin IDs", func() {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"), WithID(1))
+
+		tx := g.Transaction()
+		tx.RegisterNamed("two", func() string { return "two" }, WithID(1))
+		Expect(tx.Commit()).To(MatchError(ContainSubstring(`duplicate symbol ID 1 for plugins "one" and "two"`)))
+		Expect(g.Plugins()).To(Equal([]string{"one"}))
+	})
+
+})
@@ -0,0 +1,84 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import "golang.org/x/exp/slices"
+
+// Registration is a handle to a symbol registered using
+// [PluginGroup.Register], for later managing the registered symbol without
+// having to look it up again by its plugin name. If the symbol wasn't
+// registered in the first place, such as on unsupported platforms, the
+// Registration's methods don't have any effect.
+type Registration[T any] struct {
+	g      *PluginGroup[T]
+	plugin string
+	seq    uint64 // registration sequence number identifying the symbol, or 0.
+}
+
+// Name returns the plugin name of the registered symbol.
+func (r *Registration[T]) Name() string {
+	return r.plugin
+}
+
+// Unregister removes the registered symbol from its plugin group, running its
+// finalizer, if any. Unregister returns true if the symbol was removed, and
+// false if it had already been removed.
+func (r *Registration[T]) Unregister() bool {
+	r.g.mu.Lock()
+	var removed []Symbol[T]
+	remove := func(s Symbol[T]) bool {
+		if r.seq == 0 || s.Seq != r.seq || s.inherited {
+			return false
+		}
+		removed = append(removed, s)
+		return true
+	}
+	r.g.symbols = slices.DeleteFunc(r.g.symbols, remove)
+	r.g.hidden = slices.DeleteFunc(r.g.hidden, remove)
+	if len(removed) > 0 {
+		r.g.ordered = false
+	}
+	r.g.notify(PluginRemoved, removed...)
+	r.g.mu.Unlock()
+	finalize(removed)
+	return len(removed) > 0
+}
+
+// SetPlacement changes the placement hint of the registered symbol.
+func (r *Registration[T]) SetPlacement(placement string) {
+	r.update(func(s *Symbol[T]) { s.Placement = placement })
+}
+
+// Disable the registered symbol, so that it isn't exposed anymore while still
+// staying registered with its plugin group.
+func (r *Registration[T]) Disable() {
+	r.update(func(s *Symbol[T]) { s.disabled = true })
+}
+
+// update the registered symbol using the specified function, if the symbol is
+// still registered.
+func (r *Registration[T]) update(fn func(s *Symbol[T])) {
+	r.g.mu.Lock()
+	defer r.g.mu.Unlock()
+	for _, symbols := range [][]Symbol[T]{r.g.symbols, r.g.hidden} {
+		for idx := range symbols {
+			if r.seq != 0 && symbols[idx].Seq == r.seq && !symbols[idx].inherited {
+				fn(&symbols[idx])
+				r.g.ordered = false
+				return
+			}
+		}
+	}
+}
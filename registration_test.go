@@ -0,0 +1,58 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("registration handles", func() {
+
+	It("manages registered symbols", func() {
+		var finalized []string
+		g := &PluginGroup[fooFn]{}
+		one := g.Register(func() string { return "one" }, WithPlugin("one"),
+			WithFinalizer(func() { finalized = append(finalized, "one") }))
+		two := g.Register(func() string { return "two" }, WithPlugin("two"))
+		three := g.Register(func() string { return "three" }, WithPlugin("three"))
+		Expect(one.Name()).To(Equal("one"))
+		Expect(g.Plugins()).To(Equal([]string{"one", "three", "two"}))
+
+		two.SetPlacement("<")
+		Expect(g.Plugins()).To(Equal([]string{"two", "one", "three"}))
+
+		three.Disable()
+		Expect(g.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(g.PluginSymbol("three")).To(BeNil())
+
+		Expect(one.Unregister()).To(BeTrue())
+		Expect(one.Unregister()).To(BeFalse())
+		Expect(finalized).To(Equal([]string{"one"}))
+		Expect(g.Plugins()).To(Equal([]string{"two"}))
+		one.SetPlacement("<")
+		Expect(g.Plugins()).To(Equal([]string{"two"}))
+	})
+
+	It("doesn't manage skipped symbols", func() {
+		g := &PluginGroup[fooFn]{}
+		r := g.Register(func() string { return "none" }, WithPlugin("none"), WithPlatforms("foobar-os"))
+		Expect(r.Name()).To(Equal("none"))
+		Expect(r.Unregister()).To(BeFalse())
+		r.Disable()
+		Expect(g.Len()).To(BeZero())
+	})
+
+})
@@ -0,0 +1,157 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// RegistryDescription describes all plugin groups and their ordered plugins,
+// such as for capturing the plugin wiring of an application in different
+// environments and comparing them using [DiffRegistries]. Use
+// [MarshalRegistry] and [UnmarshalRegistry] to convert descriptions from and
+// to JSON.
+type RegistryDescription struct {
+	Groups []GroupDescription `json:"groups"` // sorted by the groups' symbol type names.
+}
+
+// GroupDescription describes a plugin group and its ordered plugins.
+type GroupDescription struct {
+	Type    string              `json:"type"`    // name of the group's symbol type.
+	Plugins []PluginDescription `json:"plugins"` // ordered plugins of the group.
+}
+
+// PluginDescription describes a plugin in a plugin group.
+type PluginDescription struct {
+	Name      string `json:"name"`
+	Placement string `json:"placement,omitempty"`
+	ID        uint32 `json:"id,omitempty"`
+	Package   string `json:"package,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// DescribeRegistry returns the description of all plugin groups and their
+// ordered plugins.
+func DescribeRegistry() RegistryDescription {
+	groups := allGroups()
+	desc := RegistryDescription{Groups: make([]GroupDescription, 0, len(groups))}
+	for _, group := range groups {
+		desc.Groups = append(desc.Groups, group.describe())
+	}
+	return desc
+}
+
+// MarshalRegistry returns the JSON description of all plugin groups and their
+// ordered plugins. See also [DescribeRegistry].
+func MarshalRegistry() ([]byte, error) {
+	return json.MarshalIndent(DescribeRegistry(), "", "  ")
+}
+
+// UnmarshalRegistry returns the registry description parsed from the JSON data
+// previously returned by [MarshalRegistry].
+func UnmarshalRegistry(data []byte) (RegistryDescription, error) {
+	var desc RegistryDescription
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return RegistryDescription{}, fmt.Errorf("invalid registry description: %w", err)
+	}
+	return desc, nil
+}
+
+// describe returns the description of this plugin group and its ordered
+// plugins.
+func (g *PluginGroup[T]) describe() GroupDescription {
+	g.lock()
+	defer g.unlock()
+	desc := GroupDescription{
+		Type:    groupKeyName(g.SymbolType()),
+		Plugins: make([]PluginDescription, 0, len(g.symbols)),
+	}
+	for _, symbol := range g.symbols {
+		desc.Plugins = append(desc.Plugins, PluginDescription{
+			Name:      symbol.Plugin,
+			Placement: symbol.Placement,
+			ID:        symbol.ID,
+			Package:   symbol.Package,
+			Version:   symbol.Version,
+		})
+	}
+	return desc
+}
+
+// DiffRegistries returns the human-readable differences between the registry
+// descriptions a and b, one difference per line, or nil if there are no
+// differences. Lines starting with "-" report groups or plugins only in a,
+// lines starting with "+" only in b, and lines starting with "~" changes.
+func DiffRegistries(a, b RegistryDescription) []string {
+	var diffs []string
+	for _, ga := range a.Groups {
+		idx := slices.IndexFunc(b.Groups, func(g GroupDescription) bool { return g.Type == ga.Type })
+		if idx < 0 {
+			diffs = append(diffs, fmt.Sprintf("- group %s", ga.Type))
+			continue
+		}
+		diffs = append(diffs, diffGroups(ga, b.Groups[idx])...)
+	}
+	for _, gb := range b.Groups {
+		if !slices.ContainsFunc(a.Groups, func(g GroupDescription) bool { return g.Type == gb.Type }) {
+			diffs = append(diffs, fmt.Sprintf("+ group %s", gb.Type))
+		}
+	}
+	return diffs
+}
+
+// diffGroups returns the human-readable differences between the descriptions
+// of the same plugin group.
+func diffGroups(a, b GroupDescription) []string {
+	var diffs []string
+	var orderA []string // order of the plugins in both a and b, as in a.
+	for _, pa := range a.Plugins {
+		idx := slices.IndexFunc(b.Plugins, func(p PluginDescription) bool { return p.Name == pa.Name })
+		if idx < 0 {
+			diffs = append(diffs, fmt.Sprintf("- group %s plugin %q", a.Type, pa.Name))
+			continue
+		}
+		orderA = append(orderA, pa.Name)
+		pb := b.Plugins[idx]
+		for _, field := range []struct{ name, a, b string }{
+			{"placement", pa.Placement, pb.Placement},
+			{"id", fmt.Sprint(pa.ID), fmt.Sprint(pb.ID)},
+			{"package", pa.Package, pb.Package},
+			{"version", pa.Version, pb.Version},
+		} {
+			if field.a != field.b {
+				diffs = append(diffs, fmt.Sprintf("~ group %s plugin %q %s %q -> %q",
+					a.Type, pa.Name, field.name, field.a, field.b))
+			}
+		}
+	}
+	var orderB []string // order of the plugins in both a and b, as in b.
+	for _, pb := range b.Plugins {
+		if !slices.ContainsFunc(a.Plugins, func(p PluginDescription) bool { return p.Name == pb.Name }) {
+			diffs = append(diffs, fmt.Sprintf("+ group %s plugin %q", b.Type, pb.Name))
+			continue
+		}
+		orderB = append(orderB, pb.Name)
+	}
+	if !slices.Equal(orderA, orderB) {
+		diffs = append(diffs, fmt.Sprintf("~ group %s order [%s] -> [%s]",
+			a.Type, strings.Join(orderA, ", "), strings.Join(orderB, ", ")))
+	}
+	return diffs
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("registry descriptions", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+		groupsByName = map[string]any{}
+	})
+
+	It("round-trips the registry description", func() {
+		Group[fooFn]().Register(func() string { return "one" }, WithPlugin("one"), WithID(42))
+		Group[fooFn]().Register(func() string { return "two" }, WithPlugin("two"),
+			WithPlacement("<"), WithVersion("1.0.0"))
+		Group[barFn]()
+
+		data, err := MarshalRegistry()
+		Expect(err).NotTo(HaveOccurred())
+		desc, err := UnmarshalRegistry(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desc).To(Equal(DescribeRegistry()))
+		Expect(desc.Groups).To(HaveExactElements(
+			And(HaveField("Type", "github.com/thediveo/go-plugger/v3.barFn"),
+				HaveField("Plugins", BeEmpty())),
+			And(HaveField("Type", "github.com/thediveo/go-plugger/v3.fooFn"),
+				HaveField("Plugins", HaveExactElements(
+					And(HaveField("Name", "two"), HaveField("Placement", "<"),
+						HaveField("Version", "1.0.0"),
+						HaveField("Package", "github.com/thediveo/go-plugger/v3")),
+					And(HaveField("Name", "one"), HaveField("ID", uint32(42))),
+				))),
+		))
+		Expect(DiffRegistries(desc, DescribeRegistry())).To(BeEmpty())
+
+		_, err = UnmarshalRegistry([]byte("{"))
+		Expect(err).To(MatchError(HavePrefix("invalid registry description: ")))
+	})
+
+	It("diffs registry descriptions", func() {
+		a := RegistryDescription{Groups: []GroupDescription{
+			{Type: "foo", Plugins: []PluginDescription{
+				{Name: "one"}, {Name: "two", Placement: "<"}, {Name: "three"}}},
+			{Type: "bar"},
+		}}
+		b := RegistryDescription{Groups: []GroupDescription{
+			{Type: "baz"},
+			{Type: "foo", Plugins: []PluginDescription{
+				{Name: "four"}, {Name: "three"}, {Name: "one", ID: 42}}},
+		}}
+		Expect(DiffRegistries(a, b)).To(Equal([]string{
+			`~ group foo plugin "one" id "0" -> "42"`,
+			`- group foo plugin "two"`,
+			`+ group foo plugin "four"`,
+			`~ group foo order [one, three] -> [three, one]`,
+			`- group bar`,
+			`+ group baz`,
+		}))
+	})
+
+})
@@ -0,0 +1,48 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import "time"
+
+// SlowPlugin reports a plugin whose callback took longer than the timeout
+// given to [PluginGroup.EachWithTimeout].
+type SlowPlugin struct {
+	Plugin   string        // name of the slow plugin.
+	Duration time.Duration // actual duration of the callback.
+	Err      error         // error returned by the callback, if any.
+}
+
+// EachWithTimeout calls fn sequentially for each symbol in this Group, in
+// order, and returns the plugins whose callbacks took longer than the
+// specified timeout d. Slow callbacks are only reported, but neither
+// cancelled nor interrupted, as Go doesn't support cancelling arbitrary
+// functions. Errors returned by fn don't stop calling fn for the remaining
+// symbols. EachWithTimeout works on a snapshot of this Group's symbols, so fn
+// can safely access this Group.
+func (g *PluginGroup[T]) EachWithTimeout(d time.Duration, fn func(T) error) []SlowPlugin {
+	var slow []SlowPlugin
+	for _, symbol := range g.PluginsSymbols() {
+		start := time.Now()
+		err := fn(symbol.S)
+		if duration := time.Since(start); duration > d {
+			slow = append(slow, SlowPlugin{
+				Plugin:   symbol.Plugin,
+				Duration: duration,
+				Err:      err,
+			})
+		}
+	}
+	return slow
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("slow plugins", func() {
+
+	It("reports plugins exceeding the timeout", func() {
+		g := &PluginGroup[fooFn]{}
+		g.Register(func() string { return "fast" }, WithPlugin("fast"))
+		g.Register(func() string { return "slow" }, WithPlugin("slow"))
+		g.Register(func() string { return "sluggish" }, WithPlugin("sluggish"))
+
+		var called []string
+		slow := g.EachWithTimeout(50*time.Millisecond, func(fn fooFn) error {
+			name := fn()
+			called = append(called, name)
+			if name == "fast" {
+				return errors.New("D'OH!")
+			}
+			time.Sleep(100 * time.Millisecond)
+			if name == "slow" {
+				return errors.New("too slow")
+			}
+			return nil
+		})
+		Expect(called).To(Equal([]string{"fast", "slow", "sluggish"}))
+		Expect(slow).To(ConsistOf(
+			And(HaveField("Plugin", "slow"),
+				HaveField("Duration", BeNumerically(">=", 100*time.Millisecond)),
+				HaveField("Err", MatchError("too slow"))),
+			And(HaveField("Plugin", "sluggish"),
+				HaveField("Err", BeNil())),
+		))
+	})
+
+})
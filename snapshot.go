@@ -0,0 +1,76 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import "golang.org/x/exp/slices"
+
+// GroupSnapshot is an immutable, consistent point-in-time view of the ordered
+// symbols of a [PluginGroup], together with the names of their plugins. A
+// GroupSnapshot can be safely retained and shared across goroutines without
+// any locking, such as when fanning out a request to all plugins concurrently.
+// Later changes to the plugin group don't affect existing snapshots.
+type GroupSnapshot[T any] struct {
+	symbols []Symbol[T] // never modified after creation.
+}
+
+// Snapshot returns an immutable point-in-time view of this plugin group's
+// ordered symbols and their plugin names.
+func (g *PluginGroup[T]) Snapshot() GroupSnapshot[T] {
+	g.lock()
+	defer g.unlock()
+
+	return GroupSnapshot[T]{symbols: materialize(slices.Clone(g.symbols))}
+}
+
+// Len returns the number of symbols in this snapshot.
+func (s GroupSnapshot[T]) Len() int {
+	return len(s.symbols)
+}
+
+// Symbol returns the symbol at the specified index in this snapshot.
+func (s GroupSnapshot[T]) Symbol(idx int) T {
+	return s.symbols[idx].S
+}
+
+// Plugin returns the name of the plugin at the specified index in this
+// snapshot.
+func (s GroupSnapshot[T]) Plugin(idx int) string {
+	return s.symbols[idx].Plugin
+}
+
+// Range calls fn sequentially for each symbol in this snapshot, passing the
+// symbol's index, its plugin name, and the symbol itself. If fn returns false,
+// Range stops the iteration.
+func (s GroupSnapshot[T]) Range(fn func(i int, name string, sym T) bool) {
+	for idx, symbol := range s.symbols {
+		if !fn(idx, symbol.Plugin, symbol.S) {
+			return
+		}
+	}
+}
+
+// Symbols returns a fresh copy of the ordered symbols in this snapshot.
+func (s GroupSnapshot[T]) Symbols() []T {
+	symbols := make([]T, 0, len(s.symbols))
+	for _, symbol := range s.symbols {
+		symbols = append(symbols, symbol.S)
+	}
+	return symbols
+}
+
+// Plugins returns a fresh copy of the ordered plugin names in this snapshot.
+func (s GroupSnapshot[T]) Plugins() []string {
+	return pluginNames(s.symbols)
+}
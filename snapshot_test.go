@@ -0,0 +1,63 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin group snapshots", func() {
+
+	It("is an immutable point-in-time view", func() {
+		g := &PluginGroup[fooFn]{}
+		Expect(g.Snapshot().Len()).To(BeZero())
+
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"), WithPlacement("<"))
+		snap := g.Snapshot()
+		g.Register(func() string { return "three" }, WithPlugin("three"), WithPlacement("<"))
+		g.Swap("three", "one")
+
+		Expect(snap.Len()).To(Equal(2))
+		Expect(snap.Plugins()).To(Equal([]string{"two", "one"}))
+		Expect(snap.Plugin(1)).To(Equal("one"))
+		Expect(snap.Symbol(0)()).To(Equal("two"))
+		Expect(snap.Symbols()).To(HaveLen(2))
+
+		var wg sync.WaitGroup
+		results := make([]string, snap.Len())
+		snap.Range(func(i int, name string, sym fooFn) bool {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = sym()
+			}()
+			return true
+		})
+		wg.Wait()
+		Expect(results).To(Equal([]string{"two", "one"}))
+
+		var ranged []string
+		snap.Range(func(i int, name string, sym fooFn) bool {
+			ranged = append(ranged, name)
+			return false
+		})
+		Expect(ranged).To(Equal([]string{"two"}))
+	})
+
+})
@@ -19,6 +19,12 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 // Symbol is a function or interface exposed by a (named) plugin. The interface
@@ -31,16 +37,79 @@ import (
 //   - "<foo": place before the plugin named "foo", if there is no such plugin
 //     named "foo", then the placement gets ignored;
 //   - ">foo": place after the plugin named "foo", if there is no such plugin
-//     named "foo", then the placement gets ignored.
+//     named "foo", then the placement gets ignored;
+//   - "~foo": place directly next to the plugin named "foo", either before or
+//     after it, whichever side moves the fewest other plugins; if there is no
+//     such plugin named "foo", then the placement gets ignored;
+//   - ">foo+2", "<foo-1": place after or before the plugin named "foo", but
+//     additionally shifted by the given number of positions, clamped to the
+//     beginning and end. For instance, ">foo+2" places two other plugins
+//     between "foo" and this plugin, if there are enough plugins.
+//
+// Multiple plugins with the same placement relative to the same named plugin,
+// such as several "<foo", are placed as a contiguous block, keeping their basic
+// order among themselves; that is, ordered by plugin name or by registration.
 type Symbol[T any] struct {
-	S         T      // exposed function or interface symbol.
-	Plugin    string // name of plugin exposing the symbol S.
-	Placement string // optional placement hint, or "".
+	S         T            // exposed function or interface symbol.
+	Plugin    string       // name of plugin exposing the symbol S.
+	Placement string       // optional placement hint, or "".
+	ID        uint32       // optional stable numeric ID, or 0.
+	Interface reflect.Type // declared interface type, if registered using RegisterAs.
+	Package   string       // import path of the registering package, if known.
+	File      string       // source file of the registration, if known.
+	Line      int          // source line of the registration, if known.
+	Seq       uint64       // registration sequence number, monotonic across all plugin groups.
+	Version   string       // optional semantic version of the plugin, or "".
+	SortKey   string       // optional key for ordering by name instead of the plugin name, or "".
+
+	finalizer    func()         // optional finalizer to run when removing this symbol.
+	fallback     bool           // default symbol, only exposed in absence of regular symbols.
+	derived      bool           // plugin name derived from the caller's directory?
+	platforms    []string       // optional allowed GOOS and GOARCH values, or nil.
+	init         func() error   // optional plugin initialization.
+	lazy         *lazySymbol[T] // optional lazy symbol construction.
+	conflicts    []string       // optional names of mutually exclusive plugins.
+	aliases      []string       // optional alternative names, in preference order.
+	config       any            // optional plugin configuration.
+	inherited    bool           // inherited from the parent group of a derived group?
+	experimental bool           // only exposed when experimental symbols are enabled?
+	disabled     bool           // disabled using Registration.Disable?
+}
+
+// lazySymbol constructs an exposed symbol only on first use, caching the
+// constructed symbol. As lazySymbol is shared between all copies of a Symbol,
+// the symbol gets constructed at most once.
+type lazySymbol[T any] struct {
+	once    sync.Once
+	factory func() T
+	s       T
+}
+
+// symbol returns the exposed symbol, constructing it first if necessary.
+func (s Symbol[T]) symbol() T {
+	if s.lazy == nil {
+		return s.S
+	}
+	s.lazy.once.Do(func() {
+		s.lazy.s = s.lazy.factory()
+		s.lazy.factory = nil
+	})
+	return s.lazy.s
 }
 
 type symbolSetter interface {
 	setPlugin(name string)
 	setPlacement(placement string)
+	setID(id uint32)
+	setFinalizer(fn func())
+	setPlatforms(platforms []string)
+	setInit(fn func() error)
+	setConflicts(names []string)
+	setAliases(names []string)
+	setConfig(config any)
+	setExperimental()
+	setVersion(version string)
+	setSortKey(key string)
 	complete(offset int, runtimeCaller func(int) (uintptr, string, int, bool))
 }
 
@@ -61,25 +130,41 @@ var _ symbolSetter = (*Symbol[any])(nil)
 // implementing value's T*). The Go compiler already ensured that the value
 // satisfies the interface type T.
 func (s Symbol[T]) Validate() {
+	s.validate(false)
+}
+
+// validate the exported plugin symbol, optionally accepting typed nil interface
+// symbols, such as intentional “null object” plugins.
+func (s Symbol[T]) validate(allowNil bool) {
 	var dummyCompositeT []T // https://stackoverflow.com/a/18316266
 	switch reflect.TypeOf(dummyCompositeT).Elem().Kind() {
 	case reflect.Func:
 		if reflect.ValueOf(s.S).IsNil() {
-			panic("func symbol must not be nil")
+			panic(fmt.Errorf("func %w", ErrNilSymbol))
 		}
 	case reflect.Interface:
 		v := reflect.ValueOf(s.S)
-		if v.Kind() == reflect.Invalid || (v.Kind() == reflect.Pointer && v.IsNil()) {
-			panic("interface symbol must not be nil")
+		if v.Kind() == reflect.Invalid || (v.Kind() == reflect.Pointer && v.IsNil() && !allowNil) {
+			panic(fmt.Errorf("interface %w", ErrNilSymbol))
 		}
 	default:
-		panic(fmt.Sprintf("symbol must be func or interface, but got %T", s.S))
+		panic(fmt.Errorf("%w: must be func or interface, but got %T", ErrInvalidSymbolType, s.S))
 	}
 }
 
+// source returns the source location of the symbol's registration in
+// “file:line” format, or "unknown location".
+func (s Symbol[T]) source() string {
+	if s.File == "" {
+		return "unknown location"
+	}
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
+
 // sets the plugin name of an exposed symbol.
 func (s *Symbol[T]) setPlugin(name string) {
 	s.Plugin = name
+	s.derived = false
 }
 
 // sets the placement hint of an exposed symbol.
@@ -87,20 +172,208 @@ func (s *Symbol[T]) setPlacement(placement string) {
 	s.Placement = placement
 }
 
+// sets the stable numeric ID of an exposed symbol.
+func (s *Symbol[T]) setID(id uint32) {
+	s.ID = id
+}
+
+// sets the finalizer of an exposed symbol.
+func (s *Symbol[T]) setFinalizer(fn func()) {
+	s.finalizer = fn
+}
+
+// sets the allowed platforms of an exposed symbol.
+func (s *Symbol[T]) setPlatforms(platforms []string) {
+	s.platforms = platforms
+}
+
+// sets the initialization function of an exposed symbol.
+func (s *Symbol[T]) setInit(fn func() error) {
+	s.init = fn
+}
+
+// sets the names of the plugins an exposed symbol conflicts with.
+func (s *Symbol[T]) setConflicts(names []string) {
+	s.conflicts = names
+}
+
+// sets the alternative names of an exposed symbol.
+func (s *Symbol[T]) setAliases(names []string) {
+	s.aliases = names
+}
+
+// sets the configuration of an exposed symbol.
+func (s *Symbol[T]) setConfig(config any) {
+	s.config = config
+}
+
+// marks an exposed symbol as experimental.
+func (s *Symbol[T]) setExperimental() {
+	s.experimental = true
+}
+
+// sets the semantic version of an exposed symbol.
+func (s *Symbol[T]) setVersion(version string) {
+	s.Version = version
+}
+
+// sets the sort key of an exposed symbol.
+func (s *Symbol[T]) setSortKey(key string) {
+	s.SortKey = key
+}
+
+// sortKey returns the key for ordering the exposed symbol by name.
+func (s Symbol[T]) sortKey() string {
+	if s.SortKey != "" {
+		return s.SortKey
+	}
+	return s.Plugin
+}
+
+// supported returns true if the exposed symbol either isn't restricted to
+// particular platforms, or the current platform is among its allowed
+// platforms.
+func (s *Symbol[T]) supported() bool {
+	if s.platforms == nil {
+		return true
+	}
+	for _, platform := range s.platforms {
+		if platform == runtime.GOOS || platform == runtime.GOARCH ||
+			platform == runtime.GOOS+"/"+runtime.GOARCH {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSymbol returns true if the specified symbol values are identical: func
+// symbols that are the very same func value, or comparable (interface) symbols
+// with the same dynamic types and values.
+//
+// Func symbols cannot be compared by their code pointers, as all closures
+// created from the same func literal, as well as all method values of the same
+// method, share the same code pointer, yet differ in their captured variables
+// or receivers.
+func sameSymbol(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return false
+	}
+	if va.Kind() == reflect.Func {
+		return funcValue(va) == funcValue(vb)
+	}
+	return va.Comparable() && va.Equal(vb)
+}
+
+// funcValue returns the pointer representing the specified func value, which
+// references both the func's code and its closure context, such as captured
+// variables or a method value's receiver. Plain functions and func literals
+// without captured variables are represented by statically allocated func
+// values, so these compare identical, while closures and method values
+// created separately are separate func values, even if they happen to share
+// the same code and context.
+func funcValue(v reflect.Value) unsafe.Pointer {
+	fn := reflect.New(v.Type())
+	fn.Elem().Set(v)
+	return *(*unsafe.Pointer)(fn.UnsafePointer())
+}
+
+// finalize runs the finalizers of the specified symbols, if any. It must not be
+// called while holding a plugin group's lock.
+func finalize[T any](symbols []Symbol[T]) {
+	for _, symbol := range symbols {
+		if symbol.finalizer != nil {
+			symbol.finalizer()
+		}
+	}
+}
+
 // completes the blanks, that is, fills in the plugin name derived from the
 // directory name of the package of the original caller (taking offset into
-// account).
+// account). Additionally, it records the package import path, as well as the
+// source file and line of the original caller.
 func (s *Symbol[T]) complete(offset int, runtimeCaller func(int) (uintptr, string, int, bool)) {
+	pc, file, line, ok := runtimeCaller(offset + 1)
+	if ok {
+		s.File, s.Line = file, line
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			s.Package = packagePath(fn.Name())
+		}
+	}
 	if s.Plugin != "" {
 		return
 	}
-	_, file, _, ok := runtimeCaller(offset + 1)
 	if !ok {
-		panic("unable to discover caller for discovering plugin name")
+		panic(fmt.Errorf("%w for discovering plugin name", ErrCallerUnknown))
 	}
-	s.Plugin = filepath.Base(filepath.Dir(file))
+	s.Plugin = deriveName(file)
+	s.derived = true
 	switch s.Plugin {
 	case "", ".", string(os.PathSeparator):
-		panic(fmt.Sprintf("cannot determine plugin name for symbol of type %T", s.S))
+		panic(fmt.Errorf("%w for symbol of type %T", ErrNoPluginName, s.S))
+	}
+}
+
+// nameDeriver optionally overrides deriving plugin names from caller files.
+var nameDeriver atomic.Pointer[func(callerFile string) string]
+
+// SetNameDeriver sets how plugin names get derived from the source files of
+// registrations not explicitly naming their plugins, such as for projects
+// with plugins located in “internal/plugins/<name>/impl/”. By default, the
+// plugin name is the name of the directory containing the caller's source
+// file. Passing nil restores the default.
+func SetNameDeriver(fn func(callerFile string) (name string)) {
+	if fn == nil {
+		nameDeriver.Store(nil)
+		return
+	}
+	nameDeriver.Store(&fn)
+}
+
+// deriveName returns the plugin name derived from the specified caller file.
+func deriveName(file string) string {
+	if fn := nameDeriver.Load(); fn != nil {
+		return (*fn)(file)
+	}
+	return filepath.Base(filepath.Dir(file))
+}
+
+// autoNameHook is the optional hook called when registering a symbol with a
+// plugin name derived from the caller's directory.
+var autoNameHook atomic.Pointer[func(name, file string)]
+
+// WarnOnAutoName sets the hook to be called whenever a symbol gets registered
+// with a plugin name derived from the directory name of the registering
+// package, passing the derived plugin name and the registering source file.
+// This allows projects to log or reject implicit plugin names, as renaming a
+// directory silently renames such plugins, breaking placements referencing
+// them. Passing nil removes the hook.
+func WarnOnAutoName(fn func(name, file string)) {
+	if fn == nil {
+		autoNameHook.Store(nil)
+		return
+	}
+	autoNameHook.Store(&fn)
+}
+
+// warnAutoName calls the auto name hook, if any, in case the plugin name of
+// the exposed symbol has been derived from the caller's directory.
+func (s Symbol[T]) warnAutoName() {
+	if !s.derived {
+		return
+	}
+	if fn := autoNameHook.Load(); fn != nil {
+		(*fn)(s.Plugin, s.File)
+	}
+}
+
+// packagePath returns the package import path part of a fully qualified
+// function name, such as "example.org/foo/bar" for "example.org/foo/bar.init.0"
+// or "example.org/foo/bar.(*Baz).Register".
+func packagePath(funcname string) string {
+	slash := strings.LastIndex(funcname, "/")
+	if dot := strings.Index(funcname[slash+1:], "."); dot >= 0 {
+		return funcname[:slash+1+dot]
 	}
+	return funcname
 }
@@ -17,6 +17,7 @@ package plugger
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -50,13 +51,13 @@ var _ = Describe("exposed plugin symbols", func() {
 	})
 
 	It("rejects nil functions and interfaces", func() {
-		Expect(Symbol[func()]{S: nil}.Validate).To(PanicWith("func symbol must not be nil"))
-		Expect(Symbol[fmt.Stringer]{S: fmt.Stringer(nil)}.Validate).To(PanicWith("interface symbol must not be nil"))
+		Expect(Symbol[func()]{S: nil}.Validate).To(PanicWith(MatchError("func symbol must not be nil")))
+		Expect(Symbol[fmt.Stringer]{S: fmt.Stringer(nil)}.Validate).To(PanicWith(MatchError("interface symbol must not be nil")))
 	})
 
 	It("rejects incorrect non-func and non-interface Symbols", func() {
-		Expect(Symbol[int]{S: 42}.Validate).To(PanicWith(
-			MatchRegexp(`^symbol must be func or interface, but got`)))
+		Expect(Symbol[int]{S: 42}.Validate).To(PanicWith(MatchError(
+			MatchRegexp(`^invalid symbol type: must be func or interface, but got`))))
 	})
 
 	It("completes the plugin name", func() {
@@ -65,6 +66,28 @@ var _ = Describe("exposed plugin symbols", func() {
 		Expect(s.Plugin).To(Equal("go-plugger"))
 	})
 
+	It("completes the plugin name using a custom name deriver", func() {
+		SetNameDeriver(func(callerFile string) string {
+			return filepath.Base(filepath.Dir(filepath.Dir(callerFile)))
+		})
+		defer SetNameDeriver(nil)
+		s := Symbol[any]{}
+		s.complete(0, func(int) (uintptr, string, int, bool) {
+			return 0, "/internal/plugins/foo/impl/impl.go", 42, true
+		})
+		Expect(s.Plugin).To(Equal("foo"))
+
+		SetNameDeriver(func(string) string { return "" })
+		Expect(func() {
+			(&Symbol[any]{}).complete(0, runtime.Caller)
+		}).To(PanicWith(MatchError("cannot determine plugin name for symbol of type <nil>")))
+
+		SetNameDeriver(nil)
+		s = Symbol[any]{}
+		s.complete(0, runtime.Caller)
+		Expect(s.Plugin).To(Equal("go-plugger"))
+	})
+
 	It("does not override an already set plugin name", func() {
 		const name = "foobarz"
 		s := Symbol[any]{Plugin: name}
@@ -72,6 +95,31 @@ var _ = Describe("exposed plugin symbols", func() {
 		Expect(s.Plugin).To(Equal(name))
 	})
 
+	It("records the source location", func() {
+		s := Symbol[any]{Plugin: "foobarz"}
+		s.complete(0, func(int) (uintptr, string, int, bool) {
+			return 0, "/foo/bar.go", 42, true
+		})
+		Expect(s.File).To(Equal("/foo/bar.go"))
+		Expect(s.Line).To(Equal(42))
+	})
+
+	It("records the package import path", func() {
+		s := Symbol[any]{}
+		s.complete(0, runtime.Caller)
+		Expect(s.Package).To(Equal("github.com/thediveo/go-plugger/v3"))
+	})
+
+	DescribeTable("extracts package import paths from function names",
+		func(funcname, expected string) {
+			Expect(packagePath(funcname)).To(Equal(expected))
+		},
+		Entry(nil, "example.org/foo/bar.init.0", "example.org/foo/bar"),
+		Entry(nil, "example.org/foo/bar.(*Baz).Register", "example.org/foo/bar"),
+		Entry(nil, "main.main", "main"),
+		Entry(nil, "foo", "foo"),
+	)
+
 	DescribeTable("panics when unable to determine the plugin name",
 		func(outcome string, expected string) {
 			s := Symbol[any]{}
@@ -82,7 +130,7 @@ var _ = Describe("exposed plugin symbols", func() {
 					}
 					return 0, outcome, 0, true
 				})
-			}).To(PanicWith(expected))
+			}).To(PanicWith(MatchError(expected)))
 		},
 		Entry("caller's file cannot be determined", "", "unable to discover caller for discovering plugin name"),
 		Entry("no directory", "foo.bar", "cannot determine plugin name for symbol of type <nil>"),
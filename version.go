@@ -0,0 +1,160 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// PluginVersion returns the semantic version of the plugin identified by its
+// name, as registered using [WithVersion], or "" if either no such named plugin
+// exists in this symbol group or it has no version.
+func (g *PluginGroup[T]) PluginVersion(name string) string {
+	g.lookupLock()
+	defer g.mu.RUnlock()
+
+	symbol, _ := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+	return symbol.Version
+}
+
+// LatestByBaseName returns the symbol with the highest semantic version among
+// the plugins sharing the specified base name and true, or the zero symbol
+// value and false if there is no such plugin. Plugins share a base name when
+// their names consist of the base name, optionally followed by "@" and a
+// version, such as "codec@1.0" and "codec@2.0" for the base name "codec". A
+// plugin's version is the version registered using [WithVersion], otherwise
+// the version following the "@" in its name. Plugins without a valid version
+// rank lowest, and ties are broken in favor of the plugin coming first in this
+// Group's order.
+func (g *PluginGroup[T]) LatestByBaseName(base string) (T, bool) {
+	g.lock()
+	defer g.unlock()
+
+	var latest *Symbol[T]
+	var latestVersion semver
+	for idx := range g.symbols {
+		symbol := &g.symbols[idx]
+		name, suffix, _ := strings.Cut(symbol.Plugin, "@")
+		if name != base {
+			continue
+		}
+		version := symbol.Version
+		if version == "" {
+			version = suffix
+		}
+		v, _ := parseSemver(version)
+		if latest == nil || v.compare(latestVersion) > 0 {
+			latest, latestVersion = symbol, v
+		}
+	}
+	if latest == nil {
+		var zero T
+		return zero, false
+	}
+	g.count(latest.Plugin)
+	return latest.symbol(), true
+}
+
+// semver is a parsed semantic version; the zero value represents an invalid or
+// missing version, ranking lower than any valid version.
+type semver struct {
+	valid               bool
+	major, minor, patch uint64
+	pre                 []string // pre-release identifiers, if any.
+}
+
+// parseSemver parses the specified semantic version with an optional leading
+// "v", such as "1.2.3", "v1.2.3-rc.1", or "1.2.3+build". For convenience, the
+// minor and patch versions can be omitted, such as in "1.0" or "v2".
+// Build metadata is ignored, as it doesn't take part in version precedence.
+func parseSemver(s string) (semver, bool) {
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return semver{}, false
+	}
+	var v semver
+	nums := []*uint64{&v.major, &v.minor, &v.patch}
+	for idx, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		*nums[idx] = n
+	}
+	if hasPre {
+		v.pre = strings.Split(pre, ".")
+		for _, id := range v.pre {
+			if id == "" {
+				return semver{}, false
+			}
+		}
+	}
+	v.valid = true
+	return v, true
+}
+
+// compare returns a negative number if v has a lower precedence than w, a
+// positive number if v has a higher precedence than w, and zero otherwise.
+func (v semver) compare(w semver) int {
+	if v.valid != w.valid {
+		if v.valid {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Or(cmp.Compare(v.major, w.major),
+		cmp.Compare(v.minor, w.minor),
+		cmp.Compare(v.patch, w.patch)); c != 0 {
+		return c
+	}
+	// A version without pre-release identifiers has a higher precedence than a
+	// pre-release version.
+	switch {
+	case len(v.pre) == 0 && len(w.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+	for idx := 0; idx < len(v.pre) && idx < len(w.pre); idx++ {
+		if c := comparePrerelease(v.pre[idx], w.pre[idx]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.pre), len(w.pre))
+}
+
+// comparePrerelease compares two pre-release identifiers: numeric identifiers
+// compare numerically and always have a lower precedence than alphanumeric
+// identifiers, which compare lexically.
+func comparePrerelease(a, b string) int {
+	an, aerr := strconv.ParseUint(a, 10, 64)
+	bn, berr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aerr == nil && berr == nil:
+		return cmp.Compare(an, bn)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
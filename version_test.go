@@ -0,0 +1,69 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin versions", func() {
+
+	DescribeTable("compares semantic versions",
+		func(a, b string, expected int) {
+			va, _ := parseSemver(a)
+			vb, _ := parseSemver(b)
+			Expect(va.compare(vb)).To(Equal(expected))
+			Expect(vb.compare(va)).To(Equal(-expected))
+		},
+		Entry(nil, "1.0.0", "1.0.0", 0),
+		Entry(nil, "v1.0", "1.0.0", 0),
+		Entry(nil, "1.0.0+build.1", "1.0.0", 0),
+		Entry(nil, "2.0.0", "1.10.0", 1),
+		Entry(nil, "1.10.0", "1.9.0", 1),
+		Entry(nil, "1.0.1", "1.0.0", 1),
+		Entry(nil, "1.0.0", "1.0.0-rc.1", 1),
+		Entry(nil, "1.0.0-rc.1", "1.0.0-beta.11", 1),
+		Entry(nil, "1.0.0-beta.11", "1.0.0-beta.2", 1),
+		Entry(nil, "1.0.0-beta", "1.0.0-alpha.1", 1),
+		Entry(nil, "1.0.0-alpha.1", "1.0.0-alpha", 1),
+		Entry(nil, "1.0.0-alpha.beta", "1.0.0-alpha.1", 1),
+		Entry(nil, "0.0.1", "foo", 1),
+		Entry(nil, "1.2.3.4", "", 0),
+		Entry(nil, "1.0.0-", "1..0", 0),
+	)
+
+	It("selects the latest version of a plugin", func() {
+		g := &PluginGroup[fooFn]{}
+		_, ok := g.LatestByBaseName("codec")
+		Expect(ok).To(BeFalse())
+
+		g.Register(func() string { return "codec@1.0" }, WithPlugin("codec@1.0"))
+		g.Register(func() string { return "codec@2.0-rc.1" }, WithPlugin("codec@2.0-rc.1"))
+		g.Register(func() string { return "codec@1.10" }, WithPlugin("codec@1.10"))
+		g.Register(func() string { return "codecs@9.0" }, WithPlugin("codecs@9.0"))
+		fn, ok := g.LatestByBaseName("codec")
+		Expect(ok).To(BeTrue())
+		Expect(fn()).To(Equal("codec@2.0-rc.1"))
+
+		g.Register(func() string { return "codec@next" }, WithPlugin("codec@next"), WithVersion("v2.0.0"))
+		fn, _ = g.LatestByBaseName("codec")
+		Expect(fn()).To(Equal("codec@next"))
+		Expect(g.PluginVersion("codec@next")).To(Equal("v2.0.0"))
+		Expect(g.PluginVersion("codec@1.0")).To(BeEmpty())
+		Expect(g.PluginVersion("foo")).To(BeEmpty())
+	})
+
+})
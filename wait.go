@@ -0,0 +1,78 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"context"
+	"reflect"
+)
+
+// WaitFor returns the exposed symbol of the plugin identified by its name,
+// waiting for the plugin to register if necessary. WaitFor returns immediately
+// if the named plugin has already been registered, otherwise it blocks until
+// either the plugin registers or the passed context is done. In the latter
+// case, WaitFor returns the zero symbol value and the context's error.
+//
+// WaitFor finds the same plugins as [PluginGroup.PluginSymbol] does, so it
+// doesn't return experimental plugins unless enabled, nor disabled plugins.
+// For derived plugin groups, WaitFor also finds and waits for plugins
+// registering with the parent groups.
+//
+// WaitFor supports staged startups where application logic cannot proceed
+// until a specific plugin, such as a dynamically loaded one, has registered.
+func (g *PluginGroup[T]) WaitFor(ctx context.Context, name string) (T, error) {
+	for {
+		// Get hold of the registration channels before looking for the plugin,
+		// so we cannot miss any registration in between.
+		cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}}
+		for group := g; group != nil; group = group.parent {
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(group.registeredChan()),
+			})
+		}
+		g.lookupLock()
+		symbol, ok := g.find(func(s Symbol[T]) bool { return s.Plugin == name })
+		g.mu.RUnlock()
+		if ok {
+			return symbol.symbol(), nil
+		}
+		if chosen, _, _ := reflect.Select(cases); chosen == 0 {
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// registeredChan returns the channel that gets closed when the next symbols
+// get registered with this plugin group.
+func (g *PluginGroup[T]) registeredChan() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.registered == nil {
+		g.registered = make(chan struct{})
+	}
+	return g.registered
+}
+
+// signal wakes up all waiters, if any, after new symbols have been registered.
+// This method must be called under write lock.
+func (g *PluginGroup[T]) signal() {
+	if g.registered == nil {
+		return
+	}
+	close(g.registered)
+	g.registered = nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("waiting for plugins", func() {
+
+	BeforeEach(func() {
+		groups = map[reflect.Type]any{}
+	})
+
+	It("returns an already registered plugin immediately", func(ctx context.Context) {
+		g := Group[fooFn]()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		fn, err := g.WaitFor(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fn()).To(Equal("one"))
+	})
+
+	It("waits for a plugin to register", func(ctx context.Context) {
+		g := Group[fooFn]()
+		ch := make(chan fooFn)
+		go func() {
+			defer GinkgoRecover()
+			fn, err := g.WaitFor(ctx, "two")
+			Expect(err).NotTo(HaveOccurred())
+			ch <- fn
+		}()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		Consistently(ch).WithTimeout(100 * time.Millisecond).ShouldNot(Receive())
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		var fn fooFn
+		Eventually(ch).Should(Receive(&fn))
+		Expect(fn()).To(Equal("two"))
+	})
+
+	It("finds only exposed plugins", func(ctx context.Context) {
+		g := Group[fooFn]()
+		g.Register(func() string { return "exp" }, WithPlugin("exp"), WithExperimental())
+		g.Register(func() string { return "gone" }, WithPlugin("gone")).Disable()
+		g.RegisterDefault(func() string { return "default" }, WithPlugin("default"))
+
+		fn, err := g.WaitFor(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fn()).To(Equal("default"))
+
+		tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = g.WaitFor(tctx, "gone")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		ch := make(chan fooFn)
+		go func() {
+			defer GinkgoRecover()
+			fn, err := g.WaitFor(ctx, "exp")
+			Expect(err).NotTo(HaveOccurred())
+			ch <- fn
+		}()
+		Consistently(ch).WithTimeout(100 * time.Millisecond).ShouldNot(Receive())
+		g.EnableExperimental(true)
+		Eventually(ch).Should(Receive(&fn))
+		Expect(fn()).To(Equal("exp"))
+	})
+
+	It("waits for plugins registering with the parent group", func(ctx context.Context) {
+		parent := Group[fooFn]()
+		child := parent.Derive()
+		ch := make(chan fooFn)
+		go func() {
+			defer GinkgoRecover()
+			fn, err := child.WaitFor(ctx, "one")
+			Expect(err).NotTo(HaveOccurred())
+			ch <- fn
+		}()
+		Consistently(ch).WithTimeout(100 * time.Millisecond).ShouldNot(Receive())
+		parent.Register(func() string { return "one" }, WithPlugin("one"))
+		var fn fooFn
+		Eventually(ch).Should(Receive(&fn))
+		Expect(fn()).To(Equal("one"))
+	})
+
+	It("gives up when the context is done", func(ctx context.Context) {
+		g := Group[fooFn]()
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		fn, err := g.WaitFor(ctx, "one")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(fn).To(BeNil())
+	})
+
+})
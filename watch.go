@@ -0,0 +1,85 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+// GroupAction identifies what happened to a plugin in a [GroupEvent].
+type GroupAction int
+
+// The actions reported by [GroupEvent] objects.
+const (
+	PluginRegistered GroupAction = iota // plugin symbol got registered.
+	PluginRemoved                       // plugin symbol got removed.
+)
+
+// String returns the name of the action, such as "registered".
+func (a GroupAction) String() string {
+	switch a {
+	case PluginRegistered:
+		return "registered"
+	case PluginRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// GroupEvent reports a plugin symbol getting registered with or removed from a
+// plugin group.
+type GroupEvent struct {
+	Plugin string      // name of the plugin.
+	Action GroupAction // what happened to the plugin's symbol.
+}
+
+// watchEventBufferSize is the capacity of the channels returned by
+// [PluginGroup.Watch].
+const watchEventBufferSize = 32
+
+// Watch returns a channel emitting events whenever plugin symbols get
+// registered with or removed from this plugin group. The events are delivered
+// without blocking the plugin group: if the channel's buffer is full, events
+// get dropped. Use [PluginGroup.StopWatch] to unsubscribe.
+func (g *PluginGroup[T]) Watch() <-chan GroupEvent {
+	ch := make(chan GroupEvent, watchEventBufferSize)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.watchers = append(g.watchers, ch)
+	return ch
+}
+
+// StopWatch unsubscribes the specified channel previously returned by
+// [PluginGroup.Watch], closing the channel.
+func (g *PluginGroup[T]) StopWatch(ch <-chan GroupEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for idx, watcher := range g.watchers {
+		if watcher == ch {
+			g.watchers = append(g.watchers[:idx], g.watchers[idx+1:]...)
+			close(watcher)
+			return
+		}
+	}
+}
+
+// notify all watchers about the specified action for the specified symbols.
+// This method must be called under write lock.
+func (g *PluginGroup[T]) notify(action GroupAction, symbols ...Symbol[T]) {
+	for _, watcher := range g.watchers {
+		for _, symbol := range symbols {
+			select {
+			case watcher <- GroupEvent{Plugin: symbol.Plugin, Action: action}:
+			default:
+			}
+		}
+	}
+}
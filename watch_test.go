@@ -0,0 +1,54 @@
+// Copyright 2022 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugger
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("watching plugin groups", func() {
+
+	It("emits registration and removal events", func() {
+		g := &PluginGroup[fooFn]{}
+		ch := g.Watch()
+		g.Register(func() string { return "one" }, WithPlugin("one"))
+		g.Register(func() string { return "two" }, WithPlugin("two"))
+		g.RemoveMatching(func(name string) bool { return name == "one" })
+		g.Clear()
+		Expect(ch).To(Receive(Equal(GroupEvent{Plugin: "one", Action: PluginRegistered})))
+		Expect(ch).To(Receive(Equal(GroupEvent{Plugin: "two", Action: PluginRegistered})))
+		Expect(ch).To(Receive(Equal(GroupEvent{Plugin: "one", Action: PluginRemoved})))
+		Expect(ch).To(Receive(Equal(GroupEvent{Plugin: "two", Action: PluginRemoved})))
+		Expect(ch).NotTo(Receive())
+		Expect(PluginRemoved.String()).To(Equal("removed"))
+
+		g.StopWatch(ch)
+		Expect(ch).To(BeClosed())
+		g.Register(func() string { return "three" }, WithPlugin("three"))
+	})
+
+	It("doesn't block on full watchers", func() {
+		g := &PluginGroup[fooFn]{}
+		ch := g.Watch()
+		for i := 0; i < watchEventBufferSize+1; i++ {
+			g.Register(func() string { return "one" }, WithPlugin(strconv.Itoa(i)))
+		}
+		Expect(ch).To(HaveLen(watchEventBufferSize))
+	})
+
+})